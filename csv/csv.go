@@ -0,0 +1,268 @@
+// Package kcsv reads CSV and TSV rows into lists of structs and writes them back out, mapping columns to fields by header
+package kcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"time"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// DefaultTimeLayout is the layout used for time.Time fields when Config.TimeLayout is not set
+const DefaultTimeLayout = time.RFC3339
+
+// Config configures a Decoder or Encoder. The zero value reads and writes comma separated values with a header row.
+type Config struct {
+	// Comma is the field delimiter, ',' if zero
+	Comma rune
+	// TimeLayout is the layout for time.Time fields, DefaultTimeLayout if empty
+	TimeLayout string
+	// AllowMissingColumns leaves fields without a matching header column at their zero value instead of failing
+	AllowMissingColumns bool
+}
+
+// TSV is a Config for tab separated values
+var TSV = Config{Comma: '\t'}
+
+func (c Config) withDefaults() Config {
+	if c.Comma == 0 {
+		c.Comma = ','
+	}
+	if c.TimeLayout == "" {
+		c.TimeLayout = DefaultTimeLayout
+	}
+	return c
+}
+
+// Decoder reads rows from CSV input into structs of type T.
+// The first row is the header; each column is matched to the field whose `csv` tag or name equals the header.
+// Columns without a matching field are ignored.
+//
+// Supported field types are strings, bools, integers, floats, time.Time, time.Duration,
+// types implementing encoding.TextUnmarshaler, and pointers to any of these. Empty cells leave the zero value.
+// Types implementing only encoding.TextMarshaler can be encoded but not decoded.
+type Decoder[T any] struct {
+	reader  *csv.Reader
+	config  Config
+	fields  []kcodec.Field
+	columns []int
+	started bool
+}
+
+// NewDecoder creates a Decoder reading from r. Panics if T is not a struct.
+func NewDecoder[T any](r io.Reader, config Config) *Decoder[T] {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("kcsv.NewDecoder: %v is not a struct", t))
+	}
+	config = config.withDefaults()
+	reader := csv.NewReader(r)
+	reader.Comma = config.Comma
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &Decoder[T]{reader: reader, config: config, fields: kcodec.StructFields(t, "csv")}
+}
+
+// Decode reads the next row, or returns io.EOF once the input is exhausted.
+// Problems with a single row, including a row too short to hold every mapped column, are returned as a RowError
+// and decoding can continue with the next row.
+//
+// Errors: io.EOF, RowError, MissingColumnError, UnsupportedTypeError
+func (d *Decoder[T]) Decode() (T, error) {
+	var item T
+	if !d.started {
+		if err := d.readHeader(); err != nil {
+			return item, err
+		}
+	}
+	record, err := d.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return item, NewRowError(parseErr.Line, parseErr.Column, "", err)
+		}
+		return item, err
+	}
+
+	value := reflect.ValueOf(&item).Elem()
+	for i, f := range d.fields {
+		column := d.columns[i]
+		if column < 0 {
+			continue
+		}
+		if column >= len(record) {
+			line, _ := d.reader.FieldPos(0)
+			return item, NewRowError(line, 0, f.Name, fmt.Errorf("%w: row has %d fields, %s is column %d", MissingColumnError, len(record), f.Name, column+1))
+		}
+		if err := kcodec.ParseText(value.FieldByIndex(f.Index), record[column], d.config.TimeLayout); err != nil {
+			line, col := d.reader.FieldPos(column)
+			return item, NewRowError(line, col, f.Name, fmt.Errorf("%w %q: %w", ConversionError, record[column], err))
+		}
+	}
+	return item, nil
+}
+
+// All returns an iterator over the remaining rows and their errors, continuing past RowErrors
+func (d *Decoder[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			item, err := d.Decode()
+			if err == io.EOF {
+				return
+			}
+			var rowErr RowError
+			if !yield(item, err) || (err != nil && !errors.As(err, &rowErr)) {
+				return
+			}
+		}
+	}
+}
+
+// Header returns the column names read from the header row, or nil before the first Decode
+func (d *Decoder[T]) Header() kl.List[string] {
+	if !d.started {
+		return nil
+	}
+	result := make(kl.List[string], 0, len(d.fields))
+	for i, f := range d.fields {
+		if d.columns[i] >= 0 {
+			result.Add(f.Name)
+		}
+	}
+	return result
+}
+
+func (d *Decoder[T]) readHeader() error {
+	for _, f := range d.fields {
+		if err := checkType(f.Type, kcodec.ParsesText); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	header, err := d.reader.Read()
+	if err != nil {
+		return err
+	}
+	// The record is reused by the next Read, so only its positions are kept
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+	d.columns = make([]int, len(d.fields))
+	for i, f := range d.fields {
+		position, ok := positions[f.Name]
+		if !ok {
+			if !d.config.AllowMissingColumns {
+				return fmt.Errorf("%w: %s", MissingColumnError, f.Name)
+			}
+			position = -1
+		}
+		d.columns[i] = position
+	}
+	d.started = true
+	return nil
+}
+
+// Read decodes every row of r into a List, stopping at the first error.
+// The rows decoded before an error are returned along with it.
+//
+// Errors: RowError, MissingColumnError, UnsupportedTypeError
+func Read[T any](r io.Reader, config Config) (kl.List[T], error) {
+	result := kl.NewList[T]()
+	for item, err := range NewDecoder[T](r, config).All() {
+		if err != nil {
+			return result, err
+		}
+		result.Add(item)
+	}
+	return result, nil
+}
+
+// ReadTSV decodes every row of tab separated r into a List, see Read
+func ReadTSV[T any](r io.Reader) (kl.List[T], error) {
+	return Read[T](r, TSV)
+}
+
+// Encoder writes structs of type T as CSV rows, preceded by a header row.
+// Columns are named and converted the same way a Decoder reads them.
+type Encoder[T any] struct {
+	writer  *csv.Writer
+	config  Config
+	fields  []kcodec.Field
+	record  []string
+	started bool
+}
+
+// NewEncoder creates an Encoder writing to w. Panics if T is not a struct.
+func NewEncoder[T any](w io.Writer, config Config) *Encoder[T] {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("kcsv.NewEncoder: %v is not a struct", t))
+	}
+	config = config.withDefaults()
+	writer := csv.NewWriter(w)
+	writer.Comma = config.Comma
+	fields := kcodec.StructFields(t, "csv")
+	return &Encoder[T]{writer: writer, config: config, fields: fields, record: make([]string, len(fields))}
+}
+
+// Encode writes items as rows, writing the header before the first one. Call Flush when done.
+//
+// Errors: UnsupportedTypeError, any error from the writer
+func (e *Encoder[T]) Encode(items ...T) error {
+	if !e.started {
+		for i, f := range e.fields {
+			if err := checkType(f.Type, kcodec.FormatsText); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			e.record[i] = f.Name
+		}
+		if err := e.writer.Write(e.record); err != nil {
+			return err
+		}
+		e.started = true
+	}
+	for _, item := range items {
+		value := reflect.ValueOf(item)
+		for i, f := range e.fields {
+			text, err := kcodec.FormatText(value.FieldByIndex(f.Index), e.config.TimeLayout)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			e.record[i] = text
+		}
+		if err := e.writer.Write(e.record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying writer
+func (e *Encoder[T]) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// Write encodes the list as CSV with a header row
+//
+// Errors: UnsupportedTypeError, any error from the writer
+func Write[T any](w io.Writer, list kl.List[T], config Config) error {
+	e := NewEncoder[T](w, config)
+	if err := e.Encode(list...); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// WriteTSV encodes the list as tab separated values, see Write
+func WriteTSV[T any](w io.Writer, list kl.List[T]) error {
+	return Write(w, list, TSV)
+}
@@ -0,0 +1,69 @@
+package kcsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	Name  string `csv:"name"`
+	Count int    `csv:"count"`
+	Note  string `csv:"note"`
+}
+
+func TestDecodeShortRow(t *testing.T) {
+	input := "name,count,note\nfull,1,x\nshort\nnext,2,y\n"
+	d := NewDecoder[row](strings.NewReader(input), Config{})
+
+	if item, err := d.Decode(); err != nil || item != (row{"full", 1, "x"}) {
+		t.Fatalf("Decode = %v, %v", item, err)
+	}
+
+	_, err := d.Decode()
+	var rowErr RowError
+	if !errors.As(err, &rowErr) || !errors.Is(err, MissingColumnError) {
+		t.Fatalf("short row Decode = %v, want a RowError wrapping MissingColumnError", err)
+	}
+	if rowErr.Line() != 3 || rowErr.Field() != "count" {
+		t.Fatalf("RowError at line %d field %q, want line 3 field count", rowErr.Line(), rowErr.Field())
+	}
+
+	// Decoding continues with the next row
+	if item, err := d.Decode(); err != nil || item != (row{"next", 2, "y"}) {
+		t.Fatalf("Decode after short row = %v, %v", item, err)
+	}
+}
+
+func TestDecodeMissingHeaderColumn(t *testing.T) {
+	items, err := Read[row](strings.NewReader("name,note\na,b\n"), Config{AllowMissingColumns: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0] != (row{Name: "a", Note: "b"}) {
+		t.Fatalf("Read = %v", items)
+	}
+}
+
+// marshalOnly can be written as text but not read back
+type marshalOnly int
+
+func (m marshalOnly) MarshalText() ([]byte, error) { return []byte("level"), nil }
+
+type marshalOnlyRow struct {
+	Level marshalOnly `csv:"level"`
+}
+
+func TestMarshalOnlyField(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []marshalOnlyRow{{1}}, Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "level\nlevel\n" {
+		t.Fatalf("Write = %q", buf.String())
+	}
+	if _, err := Read[marshalOnlyRow](&buf, Config{}); !errors.Is(err, UnsupportedTypeError) {
+		t.Fatalf("Read = %v, want UnsupportedTypeError", err)
+	}
+}
@@ -0,0 +1,51 @@
+package kcsv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Exported sentinel errors
+var (
+	MissingColumnError   = errors.New("missing column")
+	UnsupportedTypeError = errors.New("unsupported field type")
+	ConversionError      = errors.New("cannot convert value")
+)
+
+// RowError reports a problem with one row of the input, with the line and column it was found at.
+// It unwraps to the underlying error, such as ConversionError or a *csv.ParseError.
+type RowError struct {
+	line   int
+	column int
+	field  string
+	err    error
+}
+
+// NewRowError creates a RowError for the field at line and column (both 1-based, column 0 if unknown)
+func NewRowError(line int, column int, field string, err error) RowError {
+	return RowError{line: line, column: column, field: field, err: err}
+}
+
+func (e RowError) Error() string {
+	if e.field == "" {
+		return fmt.Sprintf("line %d: %v", e.line, e.err)
+	}
+	return fmt.Sprintf("line %d, column %d (%s): %v", e.line, e.column, e.field, e.err)
+}
+
+// Unwrap enables errors.Is and errors.As on the underlying error
+func (e RowError) Unwrap() error { return e.err }
+
+// Accessors for structured data without exporting fields.
+func (e RowError) Line() int     { return e.line }
+func (e RowError) Column() int   { return e.column }
+func (e RowError) Field() string { return e.field }
+
+// checkType returns UnsupportedTypeError if supported, kcodec.ParsesText or kcodec.FormatsText, rejects t
+func checkType(t reflect.Type, supported func(reflect.Type) bool) error {
+	if !supported(t) {
+		return fmt.Errorf("%w: %v", UnsupportedTypeError, t)
+	}
+	return nil
+}
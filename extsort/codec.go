@@ -0,0 +1,71 @@
+package ke
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Codec creates the encoders and decoders a Sorter uses to write items to run files and read them back
+type Codec[T any] interface {
+	NewEncoder(w io.Writer) Encoder[T]
+	NewDecoder(r io.Reader) Decoder[T]
+}
+
+// Encoder writes items to a stream
+type Encoder[T any] interface {
+	Encode(item T) error
+}
+
+// Decoder reads items from a stream written by the matching Encoder, returning io.EOF once it is exhausted
+type Decoder[T any] interface {
+	Decode() (T, error)
+}
+
+// GobCodec returns a Codec using encoding/gob, which handles most types without configuration
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+// JSONCodec returns a Codec writing one JSON value per item
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return streamEncoder[T]{encode: gob.NewEncoder(w).Encode}
+}
+
+func (gobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return streamDecoder[T]{decode: gob.NewDecoder(r).Decode}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return streamEncoder[T]{encode: json.NewEncoder(w).Encode}
+}
+
+func (jsonCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return streamDecoder[T]{decode: json.NewDecoder(r).Decode}
+}
+
+type streamEncoder[T any] struct {
+	encode func(v any) error
+}
+
+func (e streamEncoder[T]) Encode(item T) error {
+	return e.encode(item)
+}
+
+type streamDecoder[T any] struct {
+	decode func(v any) error
+}
+
+func (d streamDecoder[T]) Decode() (T, error) {
+	var item T
+	err := d.decode(&item)
+	return item, err
+}
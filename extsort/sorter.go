@@ -0,0 +1,374 @@
+// Package ke sorts lists that do not fit in memory by spilling sorted runs to temporary files and merging them
+package ke
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"unsafe"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// DefaultMemoryBudget is the memory budget used when Config.MemoryBudget is not set
+const DefaultMemoryBudget = 64 << 20
+
+// DefaultMaxFanIn is the number of run files merged at once when Config.MaxFanIn is not set
+const DefaultMaxFanIn = 64
+
+// ClosedSorterError is returned when using a Sorter after Close
+var ClosedSorterError = errors.New("sorter is closed")
+
+// Config configures a Sorter. The zero value is usable.
+type Config[T any] struct {
+	// MemoryBudget is roughly how many bytes of items are held in memory before a sorted run is spilled to disk
+	MemoryBudget int
+	// TempDir is the directory for run files, os.TempDir() if empty
+	TempDir string
+	// SizeOf estimates the memory an item uses. Defaults to its shallow size plus the length of strings.
+	SizeOf func(T) int
+	// MaxFanIn bounds how many run files are open at once while merging, at least 2.
+	// Sorted first merges groups of runs into larger ones until no more than MaxFanIn remain.
+	MaxFanIn int
+}
+
+// Sorter sorts any number of items using a bounded amount of memory.
+// Items are buffered until the memory budget is reached, then sorted and written to a temporary run file.
+// Sorted merges the runs back together. Call Close to remove the run files.
+type Sorter[T any] struct {
+	comparator func(a, b T) int
+	codec      Codec[T]
+	config     Config[T]
+	buffer     kl.List[T]
+	bufferSize int
+	runs       []string
+	count      int
+	closed     bool
+}
+
+// NewSorter creates a Sorter ordering items by the comparator and storing runs with the codec
+func NewSorter[T any](comparator func(a, b T) int, codec Codec[T], config Config[T]) *Sorter[T] {
+	if config.MemoryBudget <= 0 {
+		config.MemoryBudget = DefaultMemoryBudget
+	}
+	if config.SizeOf == nil {
+		config.SizeOf = defaultSizeOf[T]
+	}
+	if config.MaxFanIn <= 0 {
+		config.MaxFanIn = DefaultMaxFanIn
+	}
+	config.MaxFanIn = max(config.MaxFanIn, 2)
+	return &Sorter[T]{comparator: comparator, codec: codec, config: config}
+}
+
+// SortList creates a Sorter and adds the items of list to it
+//
+// Errors: any error from writing run files
+func SortList[T any](list kl.List[T], comparator func(a, b T) int, codec Codec[T], config Config[T]) (*Sorter[T], error) {
+	s := NewSorter(comparator, codec, config)
+	if err := s.Add(list...); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add items to the sorter, spilling a sorted run to disk whenever the memory budget is exceeded
+//
+// Errors: ClosedSorterError, any error from writing run files
+func (s *Sorter[T]) Add(items ...T) error {
+	if s.closed {
+		return ClosedSorterError
+	}
+	for _, item := range items {
+		s.buffer.Add(item)
+		s.bufferSize += s.config.SizeOf(item)
+		s.count++
+		if s.bufferSize >= s.config.MemoryBudget {
+			if err := s.spill(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of items added
+func (s *Sorter[T]) Len() int {
+	return s.count
+}
+
+// Runs returns the number of runs on disk, which drops when Sorted merges runs to stay within Config.MaxFanIn
+func (s *Sorter[T]) Runs() int {
+	return len(s.runs)
+}
+
+// Sorted returns an iterator over all added items in sorted order. Equal items keep the order they were added in.
+// If there are more runs than Config.MaxFanIn, they are first merged in passes into fewer, larger runs.
+// If reading or merging runs fails the iterator yields the error and stops.
+func (s *Sorter[T]) Sorted() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if s.closed {
+			yield(zero, ClosedSorterError)
+			return
+		}
+		if err := s.mergeRuns(); err != nil {
+			yield(zero, err)
+			return
+		}
+		slices.SortStableFunc(s.buffer, s.comparator)
+		// The items still in memory come last, they were added after every run
+		err := s.merge(s.runs, s.buffer, func(item T) bool { return yield(item, nil) })
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// WriteTo writes all added items in sorted order to w using the codec. It implements io.WriterTo.
+//
+// Errors: any error from reading runs or writing to w
+func (s *Sorter[T]) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	buffered := bufio.NewWriter(counter)
+	encoder := s.codec.NewEncoder(buffered)
+	for item, err := range s.Sorted() {
+		if err != nil {
+			return counter.n, err
+		}
+		if err := encoder.Encode(item); err != nil {
+			return counter.n, err
+		}
+	}
+	err := buffered.Flush()
+	return counter.n, err
+}
+
+// ToList collects all added items in sorted order into a List, which must fit in memory
+//
+// Errors: any error from reading runs
+func (s *Sorter[T]) ToList() (kl.List[T], error) {
+	result := kl.NewListCap[T](s.count)
+	for item, err := range s.Sorted() {
+		if err != nil {
+			return result, err
+		}
+		result.Add(item)
+	}
+	return result, nil
+}
+
+// Close removes the run files. The sorter cannot be used afterwards.
+func (s *Sorter[T]) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.buffer = nil
+	var errs []error
+	for _, path := range s.runs {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.runs = nil
+	return errors.Join(errs...)
+}
+
+// spill sorts the buffered items and writes them to a new run file.
+// The run is only registered once its file is completely written; on failure the buffer is kept.
+func (s *Sorter[T]) spill() error {
+	slices.SortStableFunc(s.buffer, s.comparator)
+	path, err := s.writeRun(func(encoder Encoder[T]) error {
+		for _, item := range s.buffer {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, path)
+	s.buffer = kl.NewListCap[T](s.buffer.Cap())
+	s.bufferSize = 0
+	return nil
+}
+
+// mergeRuns merges consecutive groups of runs into single runs, pass after pass, until at most MaxFanIn remain.
+// Merging only neighbouring runs keeps equal items in the order they were added.
+func (s *Sorter[T]) mergeRuns() error {
+	fanIn := s.config.MaxFanIn
+	for len(s.runs) > fanIn {
+		merged := make([]string, 0, (len(s.runs)+fanIn-1)/fanIn)
+		for start := 0; start < len(s.runs); start += fanIn {
+			group := s.runs[start:min(start+fanIn, len(s.runs))]
+			if len(group) == 1 {
+				merged = append(merged, group[0])
+				continue
+			}
+			path, err := s.writeRun(func(encoder Encoder[T]) error {
+				var encodeErr error
+				err := s.merge(group, nil, func(item T) bool {
+					encodeErr = encoder.Encode(item)
+					return encodeErr == nil
+				})
+				return errors.Join(err, encodeErr)
+			})
+			if err != nil {
+				s.runs = append(merged, s.runs[start:]...)
+				return err
+			}
+			for _, run := range group {
+				_ = os.Remove(run)
+			}
+			merged = append(merged, path)
+		}
+		s.runs = merged
+	}
+	return nil
+}
+
+// merge calls emit with the items of the runs and memory in sorted order until it returns false.
+// Ties go to the earlier run, and memory counts as the last one.
+func (s *Sorter[T]) merge(runs []string, memory []T, emit func(T) bool) error {
+	h := &runHeap[T]{comparator: s.comparator}
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	for i, path := range runs {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		if err := h.pushSource(&runSource[T]{decoder: s.codec.NewDecoder(bufio.NewReader(f)), order: i}); err != nil {
+			return err
+		}
+	}
+	if err := h.pushSource(&runSource[T]{memory: memory, order: len(runs)}); err != nil {
+		return err
+	}
+
+	for h.Len() > 0 {
+		source := h.sources[0]
+		if !emit(source.head) {
+			return nil
+		}
+		ok, err := source.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// writeRun creates a new run file and fills it using write, returning its path. On failure the file is removed.
+func (s *Sorter[T]) writeRun(write func(encoder Encoder[T]) error) (string, error) {
+	f, err := os.CreateTemp(s.config.TempDir, "keylime-extsort-*.run")
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriter(f)
+	err = write(s.codec.NewEncoder(w))
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// runSource yields the items of one sorted run, read from disk or from memory
+type runSource[T any] struct {
+	decoder Decoder[T]
+	memory  []T
+	head    T
+	order   int
+}
+
+// next advances head, returning false once the source is exhausted
+func (r *runSource[T]) next() (bool, error) {
+	if r.decoder == nil {
+		if len(r.memory) == 0 {
+			return false, nil
+		}
+		r.head, r.memory = r.memory[0], r.memory[1:]
+		return true, nil
+	}
+	item, err := r.decoder.Decode()
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	r.head = item
+	return true, nil
+}
+
+type runHeap[T any] struct {
+	sources    []*runSource[T]
+	comparator func(a, b T) int
+}
+
+func (h *runHeap[T]) pushSource(source *runSource[T]) error {
+	ok, err := source.next()
+	if ok {
+		heap.Push(h, source)
+	}
+	return err
+}
+
+func (h *runHeap[T]) Len() int { return len(h.sources) }
+func (h *runHeap[T]) Less(i, j int) bool {
+	if c := h.comparator(h.sources[i].head, h.sources[j].head); c != 0 {
+		return c < 0
+	}
+	return h.sources[i].order < h.sources[j].order
+}
+func (h *runHeap[T]) Swap(i, j int) { h.sources[i], h.sources[j] = h.sources[j], h.sources[i] }
+func (h *runHeap[T]) Push(x any)    { h.sources = append(h.sources, x.(*runSource[T])) }
+func (h *runHeap[T]) Pop() any {
+	last := h.sources[len(h.sources)-1]
+	h.sources = h.sources[:len(h.sources)-1]
+	return last
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func defaultSizeOf[T any](item T) int {
+	size := int(unsafe.Sizeof(item))
+	if s, ok := any(item).(string); ok {
+		size += len(s)
+	}
+	return size
+}
@@ -0,0 +1,299 @@
+package ke
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+type record struct {
+	Key int
+	Seq int
+}
+
+func compareKey(a, b record) int {
+	return cmp.Compare(a.Key, b.Key)
+}
+
+// smallConfig spills a run every budget items
+func smallConfig[T any](t *testing.T, budget int) Config[T] {
+	return Config[T]{MemoryBudget: budget, TempDir: t.TempDir(), SizeOf: func(T) int { return 1 }}
+}
+
+func runFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "keylime-extsort-*.run"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+func TestSorterMultiRunMerge(t *testing.T) {
+	config := smallConfig[int](t, 10)
+	s := NewSorter(cmp.Compare[int], GobCodec[int](), config)
+	defer s.Close()
+
+	items := make([]int, 95)
+	for i := range items {
+		items[i] = rand.Intn(50)
+	}
+	if err := s.Add(items...); err != nil {
+		t.Fatal(err)
+	}
+	if s.Runs() != 9 {
+		t.Fatalf("Runs = %d, want 9", s.Runs())
+	}
+	if got := len(runFiles(t, config.TempDir)); got != 9 {
+		t.Fatalf("%d run files on disk, want 9", got)
+	}
+
+	got, err := s.ToList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := slices.Sorted(slices.Values(items)); !slices.Equal(got, want) {
+		t.Fatalf("ToList = %v, want %v", got, want)
+	}
+	if s.Len() != len(items) {
+		t.Fatalf("Len = %d, want %d", s.Len(), len(items))
+	}
+}
+
+func TestSorterStable(t *testing.T) {
+	s := NewSorter(compareKey, GobCodec[record](), smallConfig[record](t, 7))
+	defer s.Close()
+
+	for i := 0; i < 100; i++ {
+		if err := s.Add(record{Key: rand.Intn(5), Seq: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := s.ToList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(got); i++ {
+		prev, curr := got[i-1], got[i]
+		if prev.Key > curr.Key || (prev.Key == curr.Key && prev.Seq > curr.Seq) {
+			t.Fatalf("items %d and %d out of order: %v, %v", i-1, i, prev, curr)
+		}
+	}
+}
+
+func TestSorterIterator(t *testing.T) {
+	s := NewSorter(cmp.Compare[int], JSONCodec[int](), smallConfig[int](t, 4))
+	defer s.Close()
+	if err := s.Add(9, 3, 7, 1, 8, 2, 6, 4, 5, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for item, err := range s.Sorted() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+		if len(got) == 5 {
+			break
+		}
+	}
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("first items = %v, want %v", got, want)
+	}
+
+	// The sorter can be iterated again after stopping early
+	all, err := s.ToList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 10 {
+		t.Fatalf("ToList after early stop has %d items, want 10", len(all))
+	}
+}
+
+func TestSorterWriteTo(t *testing.T) {
+	s := NewSorter(cmp.Compare[string], JSONCodec[string](), smallConfig[string](t, 2))
+	defer s.Close()
+	if err := s.Add("pear", "apple", "fig", "banana", "cherry"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	n, err := s.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	decoder := JSONCodec[string]().NewDecoder(&buf)
+	var got []string
+	for {
+		item, err := decoder.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+	}
+	if want := []string{"apple", "banana", "cherry", "fig", "pear"}; !slices.Equal(got, want) {
+		t.Fatalf("WriteTo output = %v, want %v", got, want)
+	}
+}
+
+func TestSorterClose(t *testing.T) {
+	config := smallConfig[int](t, 3)
+	s := NewSorter(cmp.Compare[int], GobCodec[int](), config)
+	if err := s.Add(5, 4, 3, 2, 1, 0, 9); err != nil {
+		t.Fatal(err)
+	}
+	if len(runFiles(t, config.TempDir)) == 0 {
+		t.Fatal("no run files before Close")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if files := runFiles(t, config.TempDir); len(files) != 0 {
+		t.Fatalf("run files left after Close: %v", files)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close = %v", err)
+	}
+
+	if err := s.Add(1); !errors.Is(err, ClosedSorterError) {
+		t.Fatalf("Add after Close = %v, want ClosedSorterError", err)
+	}
+	for _, err := range s.Sorted() {
+		if !errors.Is(err, ClosedSorterError) {
+			t.Fatalf("Sorted after Close = %v, want ClosedSorterError", err)
+		}
+	}
+	if _, err := s.ToList(); !errors.Is(err, ClosedSorterError) {
+		t.Fatalf("ToList after Close = %v, want ClosedSorterError", err)
+	}
+}
+
+var errEncode = errors.New("encode failed")
+
+// failingCodec fails to encode once it has encoded limit items in total
+type failingCodec struct {
+	limit   int
+	encoded *int
+}
+
+func (c failingCodec) NewEncoder(w io.Writer) Encoder[int] {
+	inner := GobCodec[int]().NewEncoder(w)
+	return encoderFunc(func(item int) error {
+		if *c.encoded >= c.limit {
+			return errEncode
+		}
+		*c.encoded++
+		return inner.Encode(item)
+	})
+}
+
+func (c failingCodec) NewDecoder(r io.Reader) Decoder[int] {
+	return GobCodec[int]().NewDecoder(r)
+}
+
+type encoderFunc func(item int) error
+
+func (f encoderFunc) Encode(item int) error { return f(item) }
+
+func TestSorterSpillFailure(t *testing.T) {
+	config := smallConfig[int](t, 4)
+	codec := failingCodec{limit: 6, encoded: new(int)}
+	s := NewSorter[int](cmp.Compare[int], codec, config)
+	defer s.Close()
+
+	// The first run is written, the second fails half way through
+	if err := s.Add(8, 7, 6, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(4, 3, 2, 1); !errors.Is(err, errEncode) {
+		t.Fatalf("Add = %v, want the encode error", err)
+	}
+	if s.Runs() != 1 {
+		t.Fatalf("Runs = %d, want 1", s.Runs())
+	}
+	if files := runFiles(t, config.TempDir); len(files) != 1 {
+		t.Fatalf("run files on disk = %v, want only the complete run", files)
+	}
+
+	got, err := s.ToList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8}; !slices.Equal(got, want) {
+		t.Fatalf("ToList after failed spill = %v, want %v", got, want)
+	}
+}
+
+func TestSortList(t *testing.T) {
+	dir := t.TempDir()
+	s, err := SortList([]int{3, 1, 2}, cmp.Compare[int], GobCodec[int](), Config[int]{TempDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	got, err := s.ToList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("ToList = %v", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("small input spilled %d files", len(entries))
+	}
+}
+
+func TestSorterBoundedFanIn(t *testing.T) {
+	config := smallConfig[record](t, 3)
+	config.MaxFanIn = 3
+	s := NewSorter(compareKey, GobCodec[record](), config)
+	defer s.Close()
+
+	for i := 0; i < 100; i++ {
+		if err := s.Add(record{Key: rand.Intn(5), Seq: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Runs() != 33 {
+		t.Fatalf("Runs = %d, want 33", s.Runs())
+	}
+
+	// 33 runs take three passes: 33 -> 11 -> 4 -> 2
+	for pass := 0; pass < 2; pass++ {
+		got, err := s.ToList()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 100 {
+			t.Fatalf("ToList returned %d items, want 100", len(got))
+		}
+		for i := 1; i < len(got); i++ {
+			prev, curr := got[i-1], got[i]
+			if prev.Key > curr.Key || (prev.Key == curr.Key && prev.Seq > curr.Seq) {
+				t.Fatalf("items %d and %d out of order: %v, %v", i-1, i, prev, curr)
+			}
+		}
+		if s.Runs() != 2 {
+			t.Fatalf("Runs after Sorted = %d, want 2", s.Runs())
+		}
+		if got := len(runFiles(t, config.TempDir)); got != 2 {
+			t.Fatalf("%d run files on disk after merging, want 2", got)
+		}
+	}
+}
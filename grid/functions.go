@@ -0,0 +1,77 @@
+package kg
+
+import kl "github.com/KeylimeVI/keylime-go/list"
+
+// Add returns the element-wise sum of two grids of the same dimensions
+//
+// Errors: DimensionMismatchError
+func Add[T kl.RealNumber](a Grid[T], b Grid[T]) (Grid[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x + y })
+}
+
+// Subtract returns the element-wise difference a - b of two grids of the same dimensions
+//
+// Errors: DimensionMismatchError
+func Subtract[T kl.RealNumber](a Grid[T], b Grid[T]) (Grid[T], error) {
+	return zipWith(a, b, func(x, y T) T { return x - y })
+}
+
+// Scale returns a new grid with every cell multiplied by factor
+func Scale[T kl.RealNumber](g Grid[T], factor T) Grid[T] {
+	result := g.Copy()
+	result.Map(func(x T) T { return x * factor })
+	return result
+}
+
+// Multiply returns the matrix product of a and b. a must have as many columns as b has rows.
+//
+// Errors: DimensionMismatchError
+func Multiply[T kl.RealNumber](a Grid[T], b Grid[T]) (Grid[T], error) {
+	if a.cols != b.rows {
+		return Grid[T]{}, DimensionMismatchError
+	}
+	result := NewGrid[T](a.rows, b.cols)
+	for r := 0; r < a.rows; r++ {
+		for k := 0; k < a.cols; k++ {
+			x := a.cells[r*a.cols+k]
+			for c := 0; c < b.cols; c++ {
+				result.cells[r*b.cols+c] += x * b.cells[k*b.cols+c]
+			}
+		}
+	}
+	return result, nil
+}
+
+// Dot returns the sum of the element-wise products of two grids of the same dimensions
+//
+// Errors: DimensionMismatchError
+func Dot[T kl.RealNumber](a Grid[T], b Grid[T]) (T, error) {
+	var sum T
+	if a.rows != b.rows || a.cols != b.cols {
+		return sum, DimensionMismatchError
+	}
+	for i := range a.cells {
+		sum += a.cells[i] * b.cells[i]
+	}
+	return sum, nil
+}
+
+// Identity returns a new size x size identity matrix
+func Identity[T kl.RealNumber](size int) Grid[T] {
+	result := NewGrid[T](size, size)
+	for i := 0; i < size; i++ {
+		result.cells[i*size+i] = 1
+	}
+	return result
+}
+
+func zipWith[T any](a Grid[T], b Grid[T], f func(x, y T) T) (Grid[T], error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Grid[T]{}, DimensionMismatchError
+	}
+	result := NewGrid[T](a.rows, a.cols)
+	for i := range result.cells {
+		result.cells[i] = f(a.cells[i], b.cells[i])
+	}
+	return result, nil
+}
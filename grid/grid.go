@@ -0,0 +1,390 @@
+// Package kg contains a generic two-dimensional grid built on kl.List
+package kg
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// Exported sentinel errors
+var (
+	DimensionMismatchError = errors.New("grid dimensions do not match")
+	RaggedRowsError        = errors.New("rows have different lengths")
+)
+
+// Grid is a generic rows x cols matrix stored in row-major order
+type Grid[T any] struct {
+	rows  int
+	cols  int
+	cells kl.List[T]
+}
+
+// Cell is a row and column position in a Grid
+type Cell struct {
+	Row int
+	Col int
+}
+
+// Connectivity selects which cells count as neighbors
+type Connectivity int
+
+const (
+	// FourConnected neighbors share an edge
+	FourConnected Connectivity = 4
+	// EightConnected neighbors share an edge or a corner
+	EightConnected Connectivity = 8
+)
+
+var fourOffsets = [...]Cell{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+var eightOffsets = [...]Cell{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// NewGrid creates a new rows x cols Grid filled with the zero value. Panics if rows or cols is negative.
+func NewGrid[T any](rows int, cols int) Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic("kg.NewGrid: negative dimension")
+	}
+	return Grid[T]{rows: rows, cols: cols, cells: make(kl.List[T], rows*cols)}
+}
+
+// NewGridFill creates a new rows x cols Grid with every cell set to value. Panics if rows or cols is negative.
+func NewGridFill[T any](rows int, cols int, value T) Grid[T] {
+	g := NewGrid[T](rows, cols)
+	for i := range g.cells {
+		g.cells[i] = value
+	}
+	return g
+}
+
+// NewGridFromRows creates a new Grid from a slice of rows
+//
+// Errors: RaggedRowsError
+func NewGridFromRows[T any, S ~[]T](rows ...S) (Grid[T], error) {
+	if len(rows) == 0 {
+		return Grid[T]{}, nil
+	}
+	cols := len(rows[0])
+	g := NewGrid[T](len(rows), cols)
+	for r, row := range rows {
+		if len(row) != cols {
+			return Grid[T]{}, RaggedRowsError
+		}
+		copy(g.cells[r*cols:], row)
+	}
+	return g, nil
+}
+
+// NewGridFromList creates a new rows x cols Grid from a row-major list of cells
+//
+// Errors: DimensionMismatchError
+func NewGridFromList[T any](rows int, cols int, list kl.List[T]) (Grid[T], error) {
+	if rows < 0 || cols < 0 || rows*cols != list.Len() {
+		return Grid[T]{}, DimensionMismatchError
+	}
+	return Grid[T]{rows: rows, cols: cols, cells: list.Copy()}, nil
+}
+
+// Rows returns the number of rows
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// Len returns the number of cells
+func (g *Grid[T]) Len() int {
+	return g.cells.Len()
+}
+
+// IsEmpty returns true if the grid has no cells
+func (g *Grid[T]) IsEmpty() bool {
+	return g.cells.IsEmpty()
+}
+
+// ValidCell checks if row and col are within the grid bounds
+func (g *Grid[T]) ValidCell(row int, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// At returns the value at row and col
+//
+// Errors: CellError
+func (g *Grid[T]) At(row int, col int) (T, error) {
+	if !g.ValidCell(row, col) {
+		var zero T
+		return zero, NewCellError(row, col, g.rows, g.cols)
+	}
+	return g.cells[row*g.cols+col], nil
+}
+
+// Set replaces the value at row and col
+//
+// Errors: CellError
+func (g *Grid[T]) Set(row int, col int, value T) error {
+	if !g.ValidCell(row, col) {
+		return NewCellError(row, col, g.rows, g.cols)
+	}
+	g.cells[row*g.cols+col] = value
+	return nil
+}
+
+// Row returns a view of row r that shares storage with the grid, so writes to it change the grid
+//
+// Errors: CellError
+func (g *Grid[T]) Row(r int) (kl.List[T], error) {
+	if r < 0 || r >= g.rows {
+		return nil, NewCellError(r, 0, g.rows, g.cols)
+	}
+	start, end := r*g.cols, (r+1)*g.cols
+	return g.cells[start:end:end], nil
+}
+
+// Column returns a view of column c that shares storage with the grid, so writes to it change the grid
+//
+// Errors: CellError
+func (g *Grid[T]) Column(c int) (ColumnView[T], error) {
+	if c < 0 || c >= g.cols {
+		return ColumnView[T]{}, NewCellError(0, c, g.rows, g.cols)
+	}
+	return ColumnView[T]{cells: g.cells, col: c, rows: g.rows, cols: g.cols}, nil
+}
+
+// ColumnView is one column of a Grid, see Grid.Column. Reads and writes go straight to the grid's cells.
+type ColumnView[T any] struct {
+	cells kl.List[T]
+	col   int
+	rows  int
+	cols  int
+}
+
+// Len returns the number of cells in the column, the number of rows of the grid
+func (v ColumnView[T]) Len() int {
+	return v.rows
+}
+
+// At returns the value in row r of the column
+//
+// Errors: CellError
+func (v ColumnView[T]) At(r int) (T, error) {
+	if r < 0 || r >= v.rows {
+		var zero T
+		return zero, NewCellError(r, v.col, v.rows, v.cols)
+	}
+	return v.cells[r*v.cols+v.col], nil
+}
+
+// Set replaces the value in row r of the column
+//
+// Errors: CellError
+func (v ColumnView[T]) Set(r int, value T) error {
+	if r < 0 || r >= v.rows {
+		return NewCellError(r, v.col, v.rows, v.cols)
+	}
+	v.cells[r*v.cols+v.col] = value
+	return nil
+}
+
+// All returns an iterator over the rows and values of the column, top to bottom
+func (v ColumnView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for r := 0; r < v.rows; r++ {
+			if !yield(r, v.cells[r*v.cols+v.col]) {
+				return
+			}
+		}
+	}
+}
+
+// ToList returns a copy of the column
+func (v ColumnView[T]) ToList() kl.List[T] {
+	result := make(kl.List[T], v.rows)
+	for r := range result {
+		result[r] = v.cells[r*v.cols+v.col]
+	}
+	return result
+}
+
+// Transpose returns a new grid with rows and columns swapped
+func (g *Grid[T]) Transpose() Grid[T] {
+	result := NewGrid[T](g.cols, g.rows)
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			result.cells[c*g.rows+r] = g.cells[r*g.cols+c]
+		}
+	}
+	return result
+}
+
+// Rotate returns a new grid rotated clockwise by the given number of quarter turns.
+// Negative turns rotate counterclockwise.
+func (g *Grid[T]) Rotate(turns int) Grid[T] {
+	turns = ((turns % 4) + 4) % 4
+	switch turns {
+	case 1:
+		result := NewGrid[T](g.cols, g.rows)
+		for r := 0; r < g.rows; r++ {
+			for c := 0; c < g.cols; c++ {
+				result.cells[c*g.rows+(g.rows-1-r)] = g.cells[r*g.cols+c]
+			}
+		}
+		return result
+	case 2:
+		result := g.Copy()
+		result.cells.Reverse()
+		return result
+	case 3:
+		result := NewGrid[T](g.cols, g.rows)
+		for r := 0; r < g.rows; r++ {
+			for c := 0; c < g.cols; c++ {
+				result.cells[(g.cols-1-c)*g.rows+r] = g.cells[r*g.cols+c]
+			}
+		}
+		return result
+	default:
+		return g.Copy()
+	}
+}
+
+// SubGrid returns a copy of the rows x cols block whose top left cell is at row and col
+//
+// Errors: CellError
+func (g *Grid[T]) SubGrid(row int, col int, rows int, cols int) (Grid[T], error) {
+	if rows < 0 || cols < 0 {
+		return Grid[T]{}, DimensionMismatchError
+	}
+	if !g.ValidCell(row, col) && rows*cols > 0 {
+		return Grid[T]{}, NewCellError(row, col, g.rows, g.cols)
+	}
+	if rows*cols > 0 && !g.ValidCell(row+rows-1, col+cols-1) {
+		return Grid[T]{}, NewCellError(row+rows-1, col+cols-1, g.rows, g.cols)
+	}
+	result := NewGrid[T](rows, cols)
+	for r := 0; r < rows; r++ {
+		copy(result.cells[r*cols:(r+1)*cols], g.cells[(row+r)*g.cols+col:])
+	}
+	return result, nil
+}
+
+// Neighbors returns an iterator over the in-bounds neighbors of row and col and their values
+func (g *Grid[T]) Neighbors(row int, col int, connectivity Connectivity) iter.Seq2[Cell, T] {
+	offsets := fourOffsets[:]
+	if connectivity == EightConnected {
+		offsets = eightOffsets[:]
+	}
+	return func(yield func(Cell, T) bool) {
+		for _, offset := range offsets {
+			r, c := row+offset.Row, col+offset.Col
+			if !g.ValidCell(r, c) {
+				continue
+			}
+			if !yield(Cell{Row: r, Col: c}, g.cells[r*g.cols+c]) {
+				return
+			}
+		}
+	}
+}
+
+// Region returns the cells connected to row and col whose values are equal to its value according to equal
+//
+// Errors: CellError
+func (g *Grid[T]) Region(row int, col int, connectivity Connectivity, equal func(a, b T) bool) (kl.List[Cell], error) {
+	start, err := g.At(row, col)
+	if err != nil {
+		return nil, err
+	}
+	visited := make([]bool, g.Len())
+	visited[row*g.cols+col] = true
+	region := kl.NewList(Cell{Row: row, Col: col})
+	for i := 0; i < region.Len(); i++ {
+		for cell, value := range g.Neighbors(region[i].Row, region[i].Col, connectivity) {
+			index := cell.Row*g.cols + cell.Col
+			if !visited[index] && equal(start, value) {
+				visited[index] = true
+				region.Add(cell)
+			}
+		}
+	}
+	return region, nil
+}
+
+// FloodFill sets value on the region connected to row and col (see Region) and returns the number of cells filled
+//
+// Errors: CellError
+func (g *Grid[T]) FloodFill(row int, col int, connectivity Connectivity, value T, equal func(a, b T) bool) (int, error) {
+	region, err := g.Region(row, col, connectivity, equal)
+	if err != nil {
+		return 0, err
+	}
+	for _, cell := range region {
+		g.cells[cell.Row*g.cols+cell.Col] = value
+	}
+	return region.Len(), nil
+}
+
+// Map applies f to each cell in place. Supports method chaining.
+func (g *Grid[T]) Map(f func(T) T) *Grid[T] {
+	g.cells.Map(f)
+	return g
+}
+
+// ForEach calls f for each cell in row-major order. Supports method chaining.
+func (g *Grid[T]) ForEach(f func(row int, col int, value T)) *Grid[T] {
+	for i, value := range g.cells {
+		f(i/g.cols, i%g.cols, value)
+	}
+	return g
+}
+
+// All returns an iterator over every cell and its value in row-major order
+func (g *Grid[T]) All() iter.Seq2[Cell, T] {
+	return func(yield func(Cell, T) bool) {
+		for i, value := range g.cells {
+			if !yield(Cell{Row: i / g.cols, Col: i % g.cols}, value) {
+				return
+			}
+		}
+	}
+}
+
+// Copy returns a new deep copy of the grid
+func (g *Grid[T]) Copy() Grid[T] {
+	return Grid[T]{rows: g.rows, cols: g.cols, cells: g.cells.Copy()}
+}
+
+// Equals compares two grids to determine if they have the same dimensions and cells
+func (g *Grid[T]) Equals(other Grid[T], optionalComparator ...func(T, T) bool) bool {
+	return g.rows == other.rows && g.cols == other.cols && g.cells.Equals(other.cells, optionalComparator...)
+}
+
+// ToList returns a copy of the cells in row-major order
+func (g *Grid[T]) ToList() kl.List[T] {
+	return g.cells.Copy()
+}
+
+// ToRows returns a copy of the grid as a list of rows
+func (g *Grid[T]) ToRows() kl.List[kl.List[T]] {
+	result := make(kl.List[kl.List[T]], g.rows)
+	for r := range result {
+		row := g.cells[r*g.cols : (r+1)*g.cols]
+		result[r] = row.Copy()
+	}
+	return result
+}
+
+// String returns the string representation of the grid, one row per line
+func (g *Grid[T]) String() string {
+	var b strings.Builder
+	for r := 0; r < g.rows; r++ {
+		if r > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%v", []T(g.cells[r*g.cols:(r+1)*g.cols]))
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package kg
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+func TestRowAndColumnViews(t *testing.T) {
+	g, err := NewGridFromRows([]int{1, 2, 3}, []int{4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := g.Row(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row[0] = 40
+
+	column, err := g.Column(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if column.Len() != 2 {
+		t.Fatalf("column Len = %d, want 2", column.Len())
+	}
+	if err := column.Set(0, 30); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := column.At(1); err != nil || v != 6 {
+		t.Fatalf("column At(1) = %d, %v, want 6", v, err)
+	}
+
+	if want := [][]int{{1, 2, 30}, {40, 5, 6}}; !slices.EqualFunc(g.ToRows(), want, func(a kl.List[int], b []int) bool {
+		return slices.Equal(a, b)
+	}) {
+		t.Fatalf("grid after writes through views = %v, want %v", g.ToRows(), want)
+	}
+
+	// Writes to the grid show through the view
+	if err := g.Set(1, 2, 60); err != nil {
+		t.Fatal(err)
+	}
+	if got := column.ToList(); !slices.Equal(got, []int{30, 60}) {
+		t.Fatalf("column ToList = %v, want [30 60]", got)
+	}
+	var rows []int
+	for r, v := range column.All() {
+		rows = append(rows, r, v)
+	}
+	if !slices.Equal(rows, []int{0, 30, 1, 60}) {
+		t.Fatalf("column All = %v", rows)
+	}
+
+	var cellErr CellError
+	if _, err := column.At(2); !errors.As(err, &cellErr) || cellErr.Row() != 2 || cellErr.Col() != 2 {
+		t.Fatalf("column At(2) = %v, want a CellError at 2, 2", err)
+	}
+	if err := column.Set(-1, 0); !errors.Is(err, kl.IndexOutOfBoundsError) {
+		t.Fatalf("column Set(-1) = %v, want IndexOutOfBoundsError", err)
+	}
+	if _, err := g.Column(3); !errors.As(err, &cellErr) {
+		t.Fatalf("Column(3) = %v, want a CellError", err)
+	}
+}
@@ -0,0 +1,34 @@
+package kg
+
+import (
+	"fmt"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// CellError provides structured context for invalid row/column access.
+// It unwraps to kl.IndexOutOfBoundsError so callers can use errors.Is.
+type CellError struct {
+	row  int
+	col  int
+	rows int
+	cols int
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("cell out of bounds: row = %d, col = %d, grid size = %dx%d", e.row, e.col, e.rows, e.cols)
+}
+
+// Unwrap enables errors.Is(err, kl.IndexOutOfBoundsError).
+func (e CellError) Unwrap() error { return kl.IndexOutOfBoundsError }
+
+// Accessors for structured data without exporting fields.
+func (e CellError) Row() int  { return e.row }
+func (e CellError) Col() int  { return e.col }
+func (e CellError) Rows() int { return e.rows }
+func (e CellError) Cols() int { return e.cols }
+
+// NewCellError constructs a typed cell error with context.
+func NewCellError(row int, col int, rows int, cols int) error {
+	return CellError{row: row, col: col, rows: rows, cols: cols}
+}
@@ -0,0 +1,400 @@
+// Package kcodec contains the compact binary format shared by the collection types' MarshalBinary methods.
+//
+// An encoding is a magic byte identifying the collection, a version byte, and one or more value blocks.
+// A value block is an element kind byte, a uvarint element count, and a payload:
+// zigzag or plain varints for integers (deltas for sorted integers), fixed-width little-endian floats,
+// length-prefixed strings, one byte per bool, or a length-prefixed gob stream for every other type.
+package kcodec
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// Version is the current encoding version
+const Version = 1
+
+const (
+	kindGob byte = iota
+	kindSigned
+	kindUnsigned
+	kindSignedDelta
+	kindUnsignedDelta
+	kindFloat
+	kindString
+	kindBool
+)
+
+// Marshal encodes items as a single value block behind the magic and version header
+func Marshal[T any](magic byte, items []T) ([]byte, error) {
+	return AppendValues([]byte{magic, Version}, items)
+}
+
+// Unmarshal decodes data written by Marshal with the same magic byte.
+// Callers wrap the returned errors in their own invalid encoding error.
+func Unmarshal[T any](magic byte, data []byte) ([]T, error) {
+	rest, err := ReadHeader(magic, data)
+	if err != nil {
+		return nil, err
+	}
+	items, rest, err := ReadValues[T](rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(rest))
+	}
+	return items, nil
+}
+
+// ReadHeader checks the magic and version bytes and returns the data after them
+func ReadHeader(magic byte, data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != magic {
+		return nil, fmt.Errorf("missing %q header", magic)
+	}
+	if data[1] != Version {
+		return nil, fmt.Errorf("unsupported version %d", data[1])
+	}
+	return data[2:], nil
+}
+
+// AppendValues appends a value block holding items to buf
+func AppendValues[T any](buf []byte, items []T) ([]byte, error) {
+	kind := kindOf[T]()
+	if kind == kindSigned && isSorted(items, readSigned[T]) {
+		kind = kindSignedDelta
+	} else if kind == kindUnsigned && isSorted(items, readUnsigned[T]) {
+		kind = kindUnsignedDelta
+	}
+	buf = append(buf, kind)
+	buf = binary.AppendUvarint(buf, uint64(len(items)))
+
+	switch kind {
+	case kindSigned:
+		for i := range items {
+			buf = binary.AppendVarint(buf, readSigned(&items[i]))
+		}
+	case kindUnsigned:
+		for i := range items {
+			buf = binary.AppendUvarint(buf, readUnsigned(&items[i]))
+		}
+	case kindSignedDelta:
+		var prev int64
+		for i := range items {
+			v := readSigned(&items[i])
+			if i == 0 {
+				buf = binary.AppendVarint(buf, v)
+			} else {
+				buf = binary.AppendUvarint(buf, uint64(v-prev))
+			}
+			prev = v
+		}
+	case kindUnsignedDelta:
+		var prev uint64
+		for i := range items {
+			v := readUnsigned(&items[i])
+			buf = binary.AppendUvarint(buf, v-prev)
+			prev = v
+		}
+	case kindFloat:
+		for i := range items {
+			if sizeOf[T]() == 4 {
+				buf = binary.LittleEndian.AppendUint32(buf, *(*uint32)(unsafe.Pointer(&items[i])))
+			} else {
+				buf = binary.LittleEndian.AppendUint64(buf, *(*uint64)(unsafe.Pointer(&items[i])))
+			}
+		}
+	case kindString:
+		for i := range items {
+			s := *(*string)(unsafe.Pointer(&items[i]))
+			buf = binary.AppendUvarint(buf, uint64(len(s)))
+			buf = append(buf, s...)
+		}
+	case kindBool:
+		for i := range items {
+			if *(*bool)(unsafe.Pointer(&items[i])) {
+				buf = append(buf, 1)
+			} else {
+				buf = append(buf, 0)
+			}
+		}
+	default:
+		var stream bytes.Buffer
+		if err := gob.NewEncoder(&stream).Encode(items); err != nil {
+			return nil, err
+		}
+		buf = binary.AppendUvarint(buf, uint64(stream.Len()))
+		buf = append(buf, stream.Bytes()...)
+	}
+	return buf, nil
+}
+
+// ReadValues decodes a value block from the start of data and returns the data after it
+func ReadValues[T any](data []byte) ([]T, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errors.New("missing value block")
+	}
+	kind := data[0]
+	data = data[1:]
+	count, data, err := uvarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	expected := kindOf[T]()
+	if kind != expected && !(kind == kindSignedDelta && expected == kindSigned) &&
+		!(kind == kindUnsignedDelta && expected == kindUnsigned) {
+		return nil, nil, fmt.Errorf("element kind %d does not match %v", kind, reflect.TypeFor[T]())
+	}
+	// Every element takes at least one byte, or its full width for floats,
+	// except in gob streams which carry their own length
+	minSize := uint64(1)
+	if kind == kindFloat {
+		minSize = uint64(sizeOf[T]())
+	}
+	if kind != kindGob && count > uint64(len(data))/minSize {
+		return nil, nil, fmt.Errorf("%d elements in %d bytes", count, len(data))
+	}
+
+	if kind == kindGob {
+		length, rest, err := uvarint(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if length > uint64(len(rest)) {
+			return nil, nil, errors.New("truncated gob stream")
+		}
+		var items []T
+		if err := gob.NewDecoder(bytes.NewReader(rest[:length])).Decode(&items); err != nil {
+			return nil, nil, err
+		}
+		if uint64(len(items)) != count {
+			return nil, nil, fmt.Errorf("expected %d elements, got %d", count, len(items))
+		}
+		return items, rest[length:], nil
+	}
+
+	items := make([]T, count)
+	var signed int64
+	var unsigned uint64
+	for i := range items {
+		switch kind {
+		case kindSigned:
+			if signed, data, err = varint(data); err != nil {
+				return nil, nil, err
+			}
+			err = writeSigned(&items[i], signed)
+		case kindSignedDelta:
+			var v int64
+			if i == 0 {
+				v, data, err = varint(data)
+			} else {
+				var delta uint64
+				delta, data, err = uvarint(data)
+				v = signed + int64(delta)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			signed = v
+			err = writeSigned(&items[i], v)
+		case kindUnsigned:
+			if unsigned, data, err = uvarint(data); err != nil {
+				return nil, nil, err
+			}
+			err = writeUnsigned(&items[i], unsigned)
+		case kindUnsignedDelta:
+			var delta uint64
+			if delta, data, err = uvarint(data); err != nil {
+				return nil, nil, err
+			}
+			unsigned += delta
+			err = writeUnsigned(&items[i], unsigned)
+		case kindFloat:
+			size := sizeOf[T]()
+			if uintptr(len(data)) < size {
+				return nil, nil, errors.New("truncated float")
+			}
+			if size == 4 {
+				*(*uint32)(unsafe.Pointer(&items[i])) = binary.LittleEndian.Uint32(data)
+			} else {
+				*(*uint64)(unsafe.Pointer(&items[i])) = binary.LittleEndian.Uint64(data)
+			}
+			data = data[size:]
+		case kindString:
+			var length uint64
+			if length, data, err = uvarint(data); err != nil {
+				return nil, nil, err
+			}
+			if length > uint64(len(data)) {
+				return nil, nil, errors.New("truncated string")
+			}
+			*(*string)(unsafe.Pointer(&items[i])) = string(data[:length])
+			data = data[length:]
+		case kindBool:
+			if len(data) == 0 || data[0] > 1 {
+				return nil, nil, errors.New("invalid bool")
+			}
+			*(*bool)(unsafe.Pointer(&items[i])) = data[0] == 1
+			data = data[1:]
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return items, data, nil
+}
+
+// SortOrdered sorts items in place when T is an integer, float or string type and reports whether it did
+func SortOrdered[T any](items []T) bool {
+	compare, ok := Comparator[T]()
+	if !ok {
+		return false
+	}
+	slices.SortFunc(items, compare)
+	return true
+}
+
+// Comparator returns the natural order of T when T is an integer, float or string type, or false otherwise.
+// The kind of T is looked up once, so the comparisons read the values directly rather than through reflection.
+func Comparator[T any]() (func(a, b T) int, bool) {
+	switch kindOf[T]() {
+	case kindSigned:
+		return func(a, b T) int { return cmp.Compare(readSigned(&a), readSigned(&b)) }, true
+	case kindUnsigned:
+		return func(a, b T) int { return cmp.Compare(readUnsigned(&a), readUnsigned(&b)) }, true
+	case kindFloat:
+		if sizeOf[T]() == 4 {
+			return func(a, b T) int {
+				return cmp.Compare(*(*float32)(unsafe.Pointer(&a)), *(*float32)(unsafe.Pointer(&b)))
+			}, true
+		}
+		return func(a, b T) int {
+			return cmp.Compare(*(*float64)(unsafe.Pointer(&a)), *(*float64)(unsafe.Pointer(&b)))
+		}, true
+	case kindString:
+		return func(a, b T) int {
+			return cmp.Compare(*(*string)(unsafe.Pointer(&a)), *(*string)(unsafe.Pointer(&b)))
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func kindOf[T any]() byte {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindSigned
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindUnsigned
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	case reflect.String:
+		return kindString
+	case reflect.Bool:
+		return kindBool
+	default:
+		return kindGob
+	}
+}
+
+func sizeOf[T any]() uintptr {
+	var zero T
+	return unsafe.Sizeof(zero)
+}
+
+func isSorted[T any, V cmp.Ordered](items []T, read func(*T) V) bool {
+	if len(items) < 2 {
+		return false
+	}
+	for i := 1; i < len(items); i++ {
+		if read(&items[i]) < read(&items[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func readSigned[T any](p *T) int64 {
+	switch sizeOf[T]() {
+	case 1:
+		return int64(*(*int8)(unsafe.Pointer(p)))
+	case 2:
+		return int64(*(*int16)(unsafe.Pointer(p)))
+	case 4:
+		return int64(*(*int32)(unsafe.Pointer(p)))
+	default:
+		return *(*int64)(unsafe.Pointer(p))
+	}
+}
+
+func readUnsigned[T any](p *T) uint64 {
+	switch sizeOf[T]() {
+	case 1:
+		return uint64(*(*uint8)(unsafe.Pointer(p)))
+	case 2:
+		return uint64(*(*uint16)(unsafe.Pointer(p)))
+	case 4:
+		return uint64(*(*uint32)(unsafe.Pointer(p)))
+	default:
+		return *(*uint64)(unsafe.Pointer(p))
+	}
+}
+
+func writeSigned[T any](p *T, v int64) error {
+	bits := sizeOf[T]() * 8
+	if bits < 64 && (v < -1<<(bits-1) || v > 1<<(bits-1)-1) {
+		return fmt.Errorf("%d overflows %v", v, reflect.TypeFor[T]())
+	}
+	switch bits {
+	case 8:
+		*(*int8)(unsafe.Pointer(p)) = int8(v)
+	case 16:
+		*(*int16)(unsafe.Pointer(p)) = int16(v)
+	case 32:
+		*(*int32)(unsafe.Pointer(p)) = int32(v)
+	default:
+		*(*int64)(unsafe.Pointer(p)) = v
+	}
+	return nil
+}
+
+func writeUnsigned[T any](p *T, v uint64) error {
+	bits := sizeOf[T]() * 8
+	if bits < 64 && v > 1<<bits-1 {
+		return fmt.Errorf("%d overflows %v", v, reflect.TypeFor[T]())
+	}
+	switch bits {
+	case 8:
+		*(*uint8)(unsafe.Pointer(p)) = uint8(v)
+	case 16:
+		*(*uint16)(unsafe.Pointer(p)) = uint16(v)
+	case 32:
+		*(*uint32)(unsafe.Pointer(p)) = uint32(v)
+	default:
+		*(*uint64)(unsafe.Pointer(p)) = v
+	}
+	return nil
+}
+
+func varint(data []byte) (int64, []byte, error) {
+	v, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("invalid varint")
+	}
+	return v, data[n:], nil
+}
+
+func uvarint(data []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("invalid uvarint")
+	}
+	return v, data[n:], nil
+}
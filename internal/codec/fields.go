@@ -0,0 +1,49 @@
+package kcodec
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Field is an exported struct field mapped to a column
+type Field struct {
+	Name  string
+	Index []int
+	Type  reflect.Type
+}
+
+// StructFields returns the columns of struct type t in field order.
+// The column name is the value of the struct tag key, or the field name if there is none; a tag of "-" skips the field.
+// Fields of embedded structs are included as if they were declared directly.
+func StructFields(t reflect.Type, key string) []Field {
+	var fields []Field
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) || throughPointer(t, f.Index) {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(key); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, Field{Name: name, Index: f.Index, Type: f.Type})
+	}
+	return fields
+}
+
+// throughPointer returns true if reaching the field at index goes through an embedded pointer
+func throughPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		f := t.Field(i)
+		if f.Type.Kind() == reflect.Pointer {
+			return true
+		}
+		t = f.Type
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package kcodec
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// ParsesText returns true if ParseText supports values of type t.
+// Types implementing encoding.TextMarshaler but not encoding.TextUnmarshaler are rejected, their text cannot be read back.
+func ParsesText(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	return !reflect.PointerTo(t).Implements(textMarshalerType) && basicText(t)
+}
+
+// FormatsText returns true if FormatText supports values of type t
+func FormatsText(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return reflect.PointerTo(t).Implements(textMarshalerType) || basicText(t)
+}
+
+// basicText returns true for the types ParseText and FormatText convert without TextMarshaler or TextUnmarshaler
+func basicText(t reflect.Type) bool {
+	if t == timeType || t == durationType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// ParseText converts text into v. Empty text leaves the zero value, and a nil pointer for pointer fields.
+func ParseText(v reflect.Value, text string, timeLayout string) error {
+	if text == "" {
+		v.SetZero()
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		t, err := time.Parse(timeLayout, text)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+// FormatText converts v to text, the inverse of ParseText
+func FormatText(v reflect.Value, timeLayout string) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(timeLayout), nil
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String(), nil
+	}
+	if reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		text, err := p.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %v", v.Type())
+	}
+}
+
+// FormatGoSyntax writes underlying, the value of named converted to its underlying type, in %#v syntax
+// with the type name of named in place of the underlying type name.
+// It lets a fmt.Formatter on a named slice or map type keep the default %#v output.
+func FormatGoSyntax(f fmt.State, named any, underlying any) {
+	text := fmt.Sprintf(fmt.FormatString(f, 'v'), underlying)
+	text = strings.TrimPrefix(text, fmt.Sprintf("%T", underlying))
+	fmt.Fprintf(f, "%T%s", named, text)
+}
@@ -0,0 +1,38 @@
+package kl
+
+import (
+	"fmt"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Integer, float, string and bool elements use a compact fixed or varint encoding,
+// sorted integer lists are delta encoded and other element types fall back to gob.
+func (l List[T]) MarshalBinary() ([]byte, error) {
+	return kcodec.Marshal('l', []T(l))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the list
+//
+// Errors: InvalidEncodingError
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	items, err := kcodec.Unmarshal[T]('l', data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", InvalidEncodingError, err)
+	}
+	*l = items
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format
+func (l List[T]) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+//
+// Errors: InvalidEncodingError
+func (l *List[T]) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
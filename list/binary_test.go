@@ -0,0 +1,102 @@
+package kl
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type binaryRecord struct {
+	Name  string
+	Score int
+}
+
+// int64sFrom reads little-endian int64s from data, dropping any trailing partial value
+func int64sFrom(data []byte) []int64 {
+	items := make([]int64, 0, len(data)/8)
+	for ; len(data) >= 8; data = data[8:] {
+		items = append(items, int64(binary.LittleEndian.Uint64(data)))
+	}
+	return items
+}
+
+// checkDecode unmarshals arbitrary data, which must either fail with InvalidEncodingError
+// or decode to a list that round trips and has no more items than there were bytes
+func checkDecode[T any](t *testing.T, data []byte, equal Equality[T]) {
+	var l List[T]
+	if err := l.UnmarshalBinary(data); err != nil {
+		if !errors.Is(err, InvalidEncodingError) {
+			t.Fatalf("UnmarshalBinary error %v is not an InvalidEncodingError", err)
+		}
+		return
+	}
+	if len(l) > len(data) {
+		t.Fatalf("decoded %d items from %d bytes", len(l), len(data))
+	}
+	encoded, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again List[T]
+	if err := again.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("re-decoding %x: %v", encoded, err)
+	}
+	if !slices.EqualFunc(l, again, equal) {
+		t.Fatalf("round trip changed %v to %v", l, again)
+	}
+}
+
+func FuzzListBinary(f *testing.F) {
+	for _, items := range [][]int64{nil, {1}, {5, 3, 9}, {-10, -2, 0, 7, 1 << 40}, {1, 1, 1, 2}} {
+		data, _ := List[int64](items).MarshalBinary()
+		f.Add(data)
+	}
+	data, _ := NewList("a", "", "ccc").MarshalBinary()
+	f.Add(data)
+	data, _ = NewList(binaryRecord{"a", 1}).MarshalBinary()
+	f.Add(data)
+	f.Add([]byte{'l', 1, 3, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Round trip the bytes as integers, both as they are and sorted for the delta encoding
+		items := int64sFrom(data)
+		for _, l := range []List[int64]{items, slices.Sorted(slices.Values(items))} {
+			encoded, err := l.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded List[int64]
+			if err := decoded.UnmarshalBinary(encoded); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(l, decoded) {
+				t.Fatalf("round trip changed %v to %v", l, decoded)
+			}
+		}
+
+		checkDecode(t, data, func(a, b int64) bool { return a == b })
+		checkDecode(t, data, func(a, b uint8) bool { return a == b })
+		checkDecode(t, data, func(a, b int16) bool { return a == b })
+		checkDecode(t, data, func(a, b string) bool { return a == b })
+		checkDecode(t, data, func(a, b float64) bool { return a == b || a != a && b != b })
+		checkDecode(t, data, func(a, b bool) bool { return a == b })
+		checkDecode(t, data, func(a, b binaryRecord) bool { return a == b })
+	})
+}
+
+func TestUnmarshalBinaryHugeCount(t *testing.T) {
+	// A signed block claiming 2^63 elements
+	var ints List[int]
+	data := []byte{'l', 1, 1, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01, 2}
+	if err := ints.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+		t.Errorf("UnmarshalBinary(%x) = %v, want InvalidEncodingError", data, err)
+	}
+
+	// A gob block claiming a stream longer than the data
+	var records List[binaryRecord]
+	data = []byte{'l', 1, 0, 1, 0xff, 0xff, 0xff, 0xff, 0x0f, 0}
+	if err := records.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+		t.Errorf("UnmarshalBinary(%x) = %v, want InvalidEncodingError", data, err)
+	}
+}
@@ -0,0 +1,179 @@
+package kl
+
+import (
+	"iter"
+	"math/big"
+)
+
+// Permutations returns an iterator over all orderings of the elements of the slice.
+// Elements are treated as distinct by position, and orderings are produced in lexicographic order of positions,
+// so a sorted input gives lexicographically sorted output. Each yielded List is a new copy.
+func Permutations[T any, S ~[]T](list S) iter.Seq[List[T]] {
+	return KPermutations(list, len(list))
+}
+
+// KPermutations returns an iterator over all orderings of k elements of the slice, see Permutations
+func KPermutations[T any, S ~[]T](list S, k int) iter.Seq[List[T]] {
+	return func(yield func(List[T]) bool) {
+		if k < 0 || k > len(list) {
+			return
+		}
+		used := make([]bool, len(list))
+		current := make(List[T], 0, k)
+		var walk func() bool
+		walk = func() bool {
+			if len(current) == k {
+				return yield(current.Copy())
+			}
+			for i, item := range list {
+				if used[i] {
+					continue
+				}
+				used[i] = true
+				current = append(current, item)
+				if !walk() {
+					return false
+				}
+				current = current[:len(current)-1]
+				used[i] = false
+			}
+			return true
+		}
+		walk()
+	}
+}
+
+// Combinations returns an iterator over all selections of k elements of the slice without repetition,
+// in lexicographic order of positions. Each yielded List is a new copy.
+func Combinations[T any, S ~[]T](list S, k int) iter.Seq[List[T]] {
+	return func(yield func(List[T]) bool) {
+		n := len(list)
+		if k < 0 || k > n {
+			return
+		}
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			if !yield(pick(list, indices)) {
+				return
+			}
+			// Find the rightmost index that can still move right
+			i := k - 1
+			for i >= 0 && indices[i] == i+n-k {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// CombinationsWithReplacement returns an iterator over all selections of k elements of the slice
+// where an element may be chosen more than once, in lexicographic order of positions. Each yielded List is a new copy.
+func CombinationsWithReplacement[T any, S ~[]T](list S, k int) iter.Seq[List[T]] {
+	return func(yield func(List[T]) bool) {
+		n := len(list)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+		indices := make([]int, k)
+		for {
+			if !yield(pick(list, indices)) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[i]
+			}
+		}
+	}
+}
+
+// CartesianProduct returns an iterator over every List taking one element from each slice,
+// in lexicographic order of positions (the last slice varies fastest). Each yielded List is a new copy.
+func CartesianProduct[T any, S ~[]T](lists ...S) iter.Seq[List[T]] {
+	return func(yield func(List[T]) bool) {
+		for _, list := range lists {
+			if len(list) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(lists))
+		for {
+			current := make(List[T], len(lists))
+			for i, index := range indices {
+				current[i] = lists[i][index]
+			}
+			if !yield(current) {
+				return
+			}
+			i := len(lists) - 1
+			for i >= 0 && indices[i] == len(lists[i])-1 {
+				indices[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+		}
+	}
+}
+
+// CountPermutations returns the number of orderings of k out of n elements, n! / (n-k)!
+func CountPermutations(n int, k int) *big.Int {
+	if k < 0 || k > n {
+		return big.NewInt(0)
+	}
+	return new(big.Int).MulRange(int64(n-k+1), int64(n))
+}
+
+// CountCombinations returns the number of selections of k out of n elements, n! / (k! (n-k)!)
+func CountCombinations(n int, k int) *big.Int {
+	if k < 0 || k > n {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// CountCombinationsWithReplacement returns the number of selections of k out of n elements with repetition
+func CountCombinationsWithReplacement(n int, k int) *big.Int {
+	if k < 0 || (n == 0 && k > 0) {
+		return big.NewInt(0)
+	}
+	if k == 0 {
+		return big.NewInt(1)
+	}
+	return CountCombinations(n+k-1, k)
+}
+
+// CountCartesianProduct returns the number of Lists CartesianProduct yields for the slices
+func CountCartesianProduct[T any, S ~[]T](lists ...S) *big.Int {
+	result := big.NewInt(1)
+	for _, list := range lists {
+		result.Mul(result, big.NewInt(int64(len(list))))
+	}
+	return result
+}
+
+func pick[T any, S ~[]T](list S, indices []int) List[T] {
+	result := make(List[T], len(indices))
+	for i, index := range indices {
+		result[i] = list[index]
+	}
+	return result
+}
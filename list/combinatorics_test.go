@@ -0,0 +1,96 @@
+package kl
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func count[T any](seq func(func(List[T]) bool)) int64 {
+	n := int64(0)
+	for range seq {
+		n++
+	}
+	return n
+}
+
+func TestCombinatoricsCounts(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	for n := 0; n <= len(items); n++ {
+		for k := -1; k <= n+1; k++ {
+			list := items[:n]
+			name := fmt.Sprintf("n=%d k=%d", n, k)
+			if got, want := count(KPermutations(list, k)), CountPermutations(n, k).Int64(); got != want {
+				t.Errorf("%s: KPermutations yielded %d, want %d", name, got, want)
+			}
+			if got, want := count(Combinations(list, k)), CountCombinations(n, k).Int64(); got != want {
+				t.Errorf("%s: Combinations yielded %d, want %d", name, got, want)
+			}
+			if got, want := count(CombinationsWithReplacement(list, k)), CountCombinationsWithReplacement(n, k).Int64(); got != want {
+				t.Errorf("%s: CombinationsWithReplacement yielded %d, want %d", name, got, want)
+			}
+		}
+		if got, want := count(Permutations(items[:n])), CountPermutations(n, n).Int64(); got != want {
+			t.Errorf("n=%d: Permutations yielded %d, want %d", n, got, want)
+		}
+	}
+
+	for _, lists := range [][][]int{
+		{},
+		{{1, 2}},
+		{{1, 2}, {3, 4, 5}},
+		{{1}, {2, 3}, {4, 5, 6, 7}},
+		{{1, 2}, {}, {3}},
+	} {
+		if got, want := count(CartesianProduct(lists...)), CountCartesianProduct(lists...).Int64(); got != want {
+			t.Errorf("CartesianProduct(%v) yielded %d, want %d", lists, got, want)
+		}
+	}
+}
+
+func TestCombinatoricsOrder(t *testing.T) {
+	collect := func(seq func(func(List[int]) bool)) [][]int {
+		var result [][]int
+		for l := range seq {
+			result = append(result, l)
+		}
+		return result
+	}
+	equal := func(a, b [][]int) bool {
+		return slices.EqualFunc(a, b, func(x, y []int) bool { return slices.Equal(x, y) })
+	}
+
+	for _, c := range []struct {
+		name string
+		got  [][]int
+		want [][]int
+	}{
+		{"Permutations", collect(Permutations([]int{1, 2, 3})),
+			[][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}}},
+		{"KPermutations", collect(KPermutations([]int{1, 2, 3}, 2)),
+			[][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}},
+		{"Combinations", collect(Combinations([]int{1, 2, 3, 4}, 2)),
+			[][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}},
+		{"CombinationsWithReplacement", collect(CombinationsWithReplacement([]int{1, 2, 3}, 2)),
+			[][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}}},
+		{"CartesianProduct", collect(CartesianProduct([]int{1, 2}, []int{3, 4})),
+			[][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}},
+		{"Combinations k=0", collect(Combinations([]int{1, 2}, 0)), [][]int{{}}},
+	} {
+		if !equal(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// Yielded lists are copies, and iteration stops when the consumer breaks
+	var kept []List[int]
+	for l := range Permutations([]int{1, 2, 3}) {
+		kept = append(kept, l)
+		if len(kept) == 2 {
+			break
+		}
+	}
+	if len(kept) != 2 || slices.Equal(kept[0], kept[1]) {
+		t.Fatalf("Permutations yielded shared or extra lists: %v", kept)
+	}
+}
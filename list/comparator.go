@@ -0,0 +1,73 @@
+package kl
+
+import "cmp"
+
+// Comparator compares two values, returning a negative number when a sorts before b,
+// a positive number when a sorts after b and zero when they are equal.
+// Comparators compose with ThenBy and Reversed and can be passed to any *Func sorting function or method.
+type Comparator[T any] func(a, b T) int
+
+// Ascending returns a Comparator for the natural order of T
+func Ascending[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// Descending returns a Comparator for the reverse natural order of T
+func Descending[T cmp.Ordered]() Comparator[T] {
+	return Ascending[T]().Reversed()
+}
+
+// By returns a Comparator ordering values by the key extracted from them.
+// Methods cannot take extra type parameters, so use By to sort a List by key: l.SortFunc(kl.By(key))
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ThenBy returns a Comparator that breaks ties of c with next
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// Reversed returns a Comparator with the opposite order of c
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// NullsFirst returns a Comparator for pointers that orders nil before everything else and compares the rest with c
+func NullsFirst[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return c(*a, *b)
+	}
+}
+
+// NullsLast returns a Comparator for pointers that orders nil after everything else and compares the rest with c
+func NullsLast[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return c(*a, *b)
+	}
+}
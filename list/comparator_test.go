@@ -0,0 +1,104 @@
+package kl
+
+import (
+	"slices"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func names(people []person) []string {
+	result := make([]string, len(people))
+	for i, p := range people {
+		result[i] = p.name
+	}
+	return result
+}
+
+func TestComparatorComposition(t *testing.T) {
+	people := List[person]{{"cy", 30}, {"al", 25}, {"bo", 30}, {"di", 25}, {"ed", 40}}
+	byAge := By(func(p person) int { return p.age })
+	byName := By(func(p person) string { return p.name })
+
+	for _, c := range []struct {
+		name       string
+		comparator Comparator[person]
+		want       []string
+	}{
+		{"By", byName, []string{"al", "bo", "cy", "di", "ed"}},
+		{"ThenBy", byAge.ThenBy(byName), []string{"al", "di", "bo", "cy", "ed"}},
+		{"Reversed ThenBy", byAge.Reversed().ThenBy(byName), []string{"ed", "bo", "cy", "al", "di"}},
+		{"ThenBy Reversed", byAge.ThenBy(byName.Reversed()), []string{"di", "al", "cy", "bo", "ed"}},
+	} {
+		sorted := people.Copy()
+		sorted.SortFunc(c.comparator)
+		if got := names(sorted); !slices.Equal(got, c.want) {
+			t.Errorf("%s: sorted %v, want %v", c.name, got, c.want)
+		}
+		if !sorted.IsSortedFunc(c.comparator) {
+			t.Errorf("%s: IsSortedFunc = false after SortFunc", c.name)
+		}
+	}
+
+	// A stable sort by age keeps the original order of equal ages
+	stable := people.Copy()
+	stable.SortStableFunc(byAge)
+	if got, want := names(stable), []string{"al", "di", "cy", "bo", "ed"}; !slices.Equal(got, want) {
+		t.Errorf("SortStableFunc = %v, want %v", got, want)
+	}
+
+	if youngest, _ := people.MinFunc(byAge); youngest.name != "al" {
+		t.Errorf("MinFunc = %v, want the first of the youngest", youngest)
+	}
+	if oldest, _ := people.MaxFunc(byAge.Reversed()); oldest.name != "al" {
+		t.Errorf("MaxFunc of reversed = %v, want the first of the youngest", oldest)
+	}
+	empty := List[person]{}
+	if _, ok := empty.MinFunc(byAge); ok {
+		t.Error("MinFunc of an empty list returned true")
+	}
+	if got := MaxBy(people, func(p person) int { return p.age }); got.name != "ed" {
+		t.Errorf("MaxBy = %v, want ed", got)
+	}
+}
+
+func TestAscendingDescending(t *testing.T) {
+	values := List[int]{3, 1, 2}
+	if values.SortFunc(Ascending[int]()); !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Ascending = %v", values)
+	}
+	if values.SortFunc(Descending[int]()); !slices.Equal(values, []int{3, 2, 1}) {
+		t.Errorf("Descending = %v", values)
+	}
+}
+
+func TestNullsFirstAndLast(t *testing.T) {
+	one, two := 1, 2
+	values := []*int{&two, nil, &one, nil}
+	deref := func(values []*int) []any {
+		result := make([]any, len(values))
+		for i, v := range values {
+			if v != nil {
+				result[i] = *v
+			}
+		}
+		return result
+	}
+
+	first := slices.Clone(values)
+	slices.SortFunc(first, NullsFirst(Ascending[int]()))
+	if got, want := deref(first), []any{nil, nil, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("NullsFirst = %v, want %v", got, want)
+	}
+	last := slices.Clone(values)
+	slices.SortFunc(last, NullsLast(Descending[int]()))
+	if got, want := deref(last), []any{2, 1, nil, nil}; !slices.Equal(got, want) {
+		t.Errorf("NullsLast = %v, want %v", got, want)
+	}
+	if NullsFirst(Ascending[int]())(nil, nil) != 0 || NullsLast(Ascending[int]())(nil, nil) != 0 {
+		t.Error("two nils do not compare equal")
+	}
+}
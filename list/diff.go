@@ -0,0 +1,327 @@
+package kl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PatchConflictError is returned by Patch when the edit script does not fit the list it is applied to
+var PatchConflictError = errors.New("edit script does not match list")
+
+// EditOp is the kind of change a Hunk describes
+type EditOp int
+
+const (
+	// EditEqual marks items present in both lists
+	EditEqual EditOp = iota
+	// EditDelete marks items only present in the first list
+	EditDelete
+	// EditInsert marks items only present in the second list
+	EditInsert
+)
+
+func (op EditOp) String() string {
+	switch op {
+	case EditEqual:
+		return "equal"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	default:
+		return fmt.Sprintf("EditOp(%d)", int(op))
+	}
+}
+
+// Hunk is a run of consecutive items with the same EditOp.
+// AStart:AEnd is its range in the first list and BStart:BEnd its range in the second;
+// the range of the list the items are absent from is empty.
+// Items holds the items of the hunk, taken from the second list for insertions and from the first otherwise.
+type Hunk[T any] struct {
+	Op     EditOp
+	AStart int
+	AEnd   int
+	BStart int
+	BEnd   int
+	Items  List[T]
+}
+
+// Diff returns the shortest edit script turning a into b, computed with Myers' O((n+m)d) algorithm
+func Diff[T comparable, S ~[]T](a S, b S) List[Hunk[T]] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// DiffFunc returns the shortest edit script turning a into b using equal to compare items, see Diff
+func DiffFunc[T any, S ~[]T](a S, b S, equal func(x, y T) bool) List[Hunk[T]] {
+	ops := myers(a, b, equal)
+	result := NewList[Hunk[T]]()
+	x, y := 0, 0
+	for start := 0; start < len(ops); {
+		end := start
+		for end < len(ops) && ops[end] == ops[start] {
+			end++
+		}
+		count := end - start
+		hunk := Hunk[T]{Op: ops[start], AStart: x, AEnd: x, BStart: y, BEnd: y}
+		switch hunk.Op {
+		case EditEqual:
+			hunk.AEnd, hunk.BEnd = x+count, y+count
+			hunk.Items = copyList(List[T](a[x : x+count]))
+		case EditDelete:
+			hunk.AEnd = x + count
+			hunk.Items = copyList(List[T](a[x : x+count]))
+		case EditInsert:
+			hunk.BEnd = y + count
+			hunk.Items = copyList(List[T](b[y : y+count]))
+		}
+		x, y = hunk.AEnd, hunk.BEnd
+		result.Add(hunk)
+		start = end
+	}
+	return result
+}
+
+// LongestCommonSubsequence returns a longest list of items appearing in both a and b in the same order
+func LongestCommonSubsequence[T comparable, S ~[]T](a S, b S) List[T] {
+	return LongestCommonSubsequenceFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// LongestCommonSubsequenceFunc returns a longest common subsequence of a and b using equal to compare items
+func LongestCommonSubsequenceFunc[T any, S ~[]T](a S, b S, equal func(x, y T) bool) List[T] {
+	result := NewList[T]()
+	for _, hunk := range DiffFunc(a, b, equal) {
+		if hunk.Op == EditEqual {
+			result.Add(hunk.Items...)
+		}
+	}
+	return result
+}
+
+// EditDistance returns the Levenshtein distance between a and b: the fewest insertions, deletions and substitutions turning a into b
+func EditDistance[T comparable, S ~[]T](a S, b S) int {
+	return EditDistanceFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EditDistanceFunc returns the Levenshtein distance between a and b using equal to compare items
+func EditDistanceFunc[T any, S ~[]T](a S, b S, equal func(x, y T) bool) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := prev[j-1]
+			if !equal(a[i-1], b[j-1]) {
+				substitution++
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, substitution)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// UnifiedDiff renders an edit script in unified diff format with context lines of unchanged items around each change
+func UnifiedDiff[T fmt.Stringer](script List[Hunk[T]], context int) string {
+	return UnifiedDiffFunc(script, context, T.String)
+}
+
+// UnifiedDiffFunc renders an edit script in unified diff format using format to turn items into lines, see UnifiedDiff
+func UnifiedDiffFunc[T any](script List[Hunk[T]], context int, format func(T) string) string {
+	type line struct {
+		op   EditOp
+		a, b int
+		text string
+	}
+	var lines []line
+	for _, hunk := range script {
+		for i, item := range hunk.Items {
+			l := line{op: hunk.Op, a: hunk.AStart, b: hunk.BStart, text: format(item)}
+			if hunk.Op != EditInsert {
+				l.a += i
+			}
+			if hunk.Op != EditDelete {
+				l.b += i
+			}
+			lines = append(lines, l)
+		}
+	}
+	context = max(context, 0)
+
+	var out strings.Builder
+	for i := 0; i < len(lines); {
+		if lines[i].op == EditEqual {
+			i++
+			continue
+		}
+		// Grow the block while the next change is within two contexts of the previous one
+		start := max(i-context, 0)
+		end := i
+		for end < len(lines) {
+			if lines[end].op != EditEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].op == EditEqual {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				end = min(end+context, len(lines))
+				break
+			}
+			end = next
+		}
+
+		aStart, bStart, aLen, bLen := lines[start].a, lines[start].b, 0, 0
+		for _, l := range lines[start:end] {
+			if l.op != EditInsert {
+				aLen++
+			}
+			if l.op != EditDelete {
+				bLen++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", unifiedRange(aStart, aLen), unifiedRange(bStart, bLen))
+		for _, l := range lines[start:end] {
+			prefix := " "
+			switch l.op {
+			case EditDelete:
+				prefix = "-"
+			case EditInsert:
+				prefix = "+"
+			}
+			out.WriteString(prefix)
+			out.WriteString(l.text)
+			out.WriteByte('\n')
+		}
+		i = end
+	}
+	return out.String()
+}
+
+// Patch applies an edit script produced by Diff or DiffFunc to list and returns the patched result.
+// Applying Diff(a, b) to a gives b. The items of equal and delete hunks must match the items of list they cover.
+//
+// Errors: PatchConflictError
+func Patch[T comparable, S ~[]T](list S, script List[Hunk[T]]) (List[T], error) {
+	return PatchFunc(list, script, func(x, y T) bool { return x == y })
+}
+
+// PatchFunc applies an edit script to list using equal to check the items of equal and delete hunks, see Patch
+//
+// Errors: PatchConflictError
+func PatchFunc[T any, S ~[]T](list S, script List[Hunk[T]], equal func(x, y T) bool) (List[T], error) {
+	result := NewListCap[T](len(list))
+	position := 0
+	for _, hunk := range script {
+		if hunk.AStart != position || hunk.AEnd < hunk.AStart || hunk.AEnd > len(list) {
+			return nil, fmt.Errorf("%w: hunk at %d:%d, list position %d of %d", PatchConflictError, hunk.AStart, hunk.AEnd, position, len(list))
+		}
+		switch hunk.Op {
+		case EditEqual, EditDelete:
+			if len(hunk.Items) != hunk.AEnd-hunk.AStart {
+				return nil, fmt.Errorf("%w: %v hunk at %d:%d holds %d items", PatchConflictError, hunk.Op, hunk.AStart, hunk.AEnd, len(hunk.Items))
+			}
+			for i, item := range hunk.Items {
+				if !equal(item, list[hunk.AStart+i]) {
+					return nil, fmt.Errorf("%w: %v hunk item %d differs from list item %d", PatchConflictError, hunk.Op, i, hunk.AStart+i)
+				}
+			}
+			if hunk.Op == EditEqual {
+				result.Add(list[hunk.AStart:hunk.AEnd]...)
+			}
+		case EditInsert:
+			if hunk.AEnd != hunk.AStart {
+				return nil, fmt.Errorf("%w: insert hunk spans %d:%d", PatchConflictError, hunk.AStart, hunk.AEnd)
+			}
+			result.Add(hunk.Items...)
+		default:
+			return nil, fmt.Errorf("%w: unknown op %v", PatchConflictError, hunk.Op)
+		}
+		position = hunk.AEnd
+	}
+	if position != len(list) {
+		return nil, fmt.Errorf("%w: script ends at %d, list has %d items", PatchConflictError, position, len(list))
+	}
+	return result, nil
+}
+
+func unifiedRange(start int, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// myers returns one EditOp per item of the shortest edit script turning a into b.
+// Each step d only reads diagonals -d-1 to d+1, so only that window of v is kept for the backtrack, using O(d²) memory.
+func myers[T any, S ~[]T](a S, b S, equal func(x, y T) bool) []EditOp {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && equal(a[x], b[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	ops := make([]EditOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		// The window for step d starts at diagonal -d-1
+		v, offset := trace[d], d+1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, EditEqual)
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, EditInsert)
+			} else {
+				ops = append(ops, EditDelete)
+			}
+			x, y = prevX, prevY
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
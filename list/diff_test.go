@@ -0,0 +1,98 @@
+package kl
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// lcsLength is the quadratic dynamic programming answer Diff must match
+func lcsLength(a, b []int) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func randomInts(n, values int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = rand.Intn(values)
+	}
+	return items
+}
+
+func TestDiffIsShortestAndPatches(t *testing.T) {
+	for range 200 {
+		a, b := randomInts(rand.Intn(40), 4), randomInts(rand.Intn(40), 4)
+		script := Diff(a, b)
+
+		edits, common := 0, 0
+		for _, hunk := range script {
+			if hunk.Op == EditEqual {
+				common += len(hunk.Items)
+			} else {
+				edits += len(hunk.Items)
+			}
+		}
+		if want := lcsLength(a, b); common != want {
+			t.Fatalf("Diff(%v, %v) keeps %d items, the LCS has %d", a, b, common, want)
+		}
+		if edits != len(a)+len(b)-2*common {
+			t.Fatalf("Diff(%v, %v) has %d edits, want %d", a, b, edits, len(a)+len(b)-2*common)
+		}
+
+		patched, err := Patch(a, script)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(patched, b) {
+			t.Fatalf("Patch(a, Diff(a, b)) = %v, want %v", patched, b)
+		}
+	}
+}
+
+func TestPatchConflict(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	script := Diff(a, []int{1, 3, 4, 5})
+
+	// Same length, different items
+	if _, err := Patch([]int{9, 9, 9, 9}, script); !errors.Is(err, PatchConflictError) {
+		t.Fatalf("Patch on a different list = %v, want PatchConflictError", err)
+	}
+	if _, err := Patch([]int{1, 2, 3}, script); !errors.Is(err, PatchConflictError) {
+		t.Fatalf("Patch on a shorter list = %v, want PatchConflictError", err)
+	}
+
+	fold := func(x, y string) bool { return strings.EqualFold(x, y) }
+	words := DiffFunc([]string{"a", "B"}, []string{"b", "c"}, fold)
+	patched, err := PatchFunc([]string{"A", "b"}, words, fold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(patched, []string{"b", "c"}) {
+		t.Fatalf("PatchFunc = %v, want [b c]", patched)
+	}
+}
+
+func TestDiffVeryDifferent(t *testing.T) {
+	a, b := make([]int, 3000), make([]int, 3000)
+	for i := range a {
+		a[i], b[i] = i, -i-1
+	}
+	script := Diff(a, b)
+	if len(script) != 2 || script[0].Op != EditDelete || script[1].Op != EditInsert {
+		t.Fatalf("Diff of disjoint lists = %d hunks, want one delete and one insert", len(script))
+	}
+}
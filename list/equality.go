@@ -0,0 +1,127 @@
+package kl
+
+import "reflect"
+
+// Equality decides whether two values of T are equal
+type Equality[T any] func(a, b T) bool
+
+// DefaultEquality returns the equality the search methods use when none is given:
+// == for comparable types and reflect.DeepEqual otherwise.
+// The choice is made once from the type, so comparing strictly comparable values does not use reflection or allocate.
+// For interface types it is made per value, from the dynamic type.
+// Types that are comparable only because of interface fields or elements, whose == panics when they hold
+// slices, maps or funcs, are compared with == and fall back to reflect.DeepEqual when it panics.
+func DefaultEquality[T any]() Equality[T] {
+	t := reflect.TypeFor[T]()
+	switch {
+	case t.Kind() == reflect.Interface:
+		return func(a, b T) bool {
+			if dynamic := reflect.TypeOf(a); dynamic == nil || dynamic.Comparable() {
+				return interfaceEqual(any(a), any(b))
+			}
+			return reflect.DeepEqual(a, b)
+		}
+	case t.Comparable() && holdsInterface(t):
+		return func(a, b T) bool {
+			return interfaceEqual(any(a), any(b))
+		}
+	case t.Comparable():
+		return func(a, b T) bool {
+			return any(a) == any(b)
+		}
+	default:
+		return func(a, b T) bool {
+			return reflect.DeepEqual(a, b)
+		}
+	}
+}
+
+// interfaceEqual compares a and b with ==, using reflect.DeepEqual instead when == panics on an uncomparable dynamic value
+func interfaceEqual(a, b any) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
+
+// holdsInterface returns true if a comparable type has interface values among its fields or array elements
+func holdsInterface(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Array:
+		return holdsInterface(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if holdsInterface(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ContainsFunc returns true if predicate returns true for at least one item
+func (l *List[T]) ContainsFunc(predicate func(T) bool) bool {
+	_, ok := l.IndexOfFunc(predicate)
+	return ok
+}
+
+// IndexOfFunc returns the index of the first item for which predicate returns true; otherwise (-1, false).
+func (l *List[T]) IndexOfFunc(predicate func(T) bool) (int, bool) {
+	for i, item := range *l {
+		if predicate(item) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// EqualsWith returns true if both lists have the same length and equal items at every index
+func (l *List[T]) EqualsWith(other List[T], equal Equality[T]) bool {
+	if len(*l) != len(other) {
+		return false
+	}
+	for i, item := range *l {
+		if !equal(item, other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DistinctBy returns the items of the slice with a key not seen before, keeping the first occurrence of each key
+func DistinctBy[T any, K comparable, S ~[]T](list S, key func(T) K) S {
+	seen := make(map[K]struct{}, len(list))
+	result := make(S, 0, len(list))
+	for _, item := range list {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		result = append(result, item)
+	}
+	return result
+}
+
+// DistinctFunc returns the items of the slice not equal to an earlier item, keeping the first occurrence.
+// It compares every pair of items, so prefer DistinctBy when items have a comparable key.
+func DistinctFunc[T any, S ~[]T](list S, equal Equality[T]) S {
+	result := make(S, 0, len(list))
+	for _, item := range list {
+		duplicate := false
+		for _, kept := range result {
+			if equal(kept, item) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package kl
+
+import "testing"
+
+type tagged struct {
+	Name  string
+	Value any
+}
+
+func TestDefaultEqualityInterfaceFields(t *testing.T) {
+	equal := DefaultEquality[tagged]()
+	if !equal(tagged{"a", []int{1}}, tagged{"a", []int{1}}) {
+		t.Error("structs holding equal slices compare unequal")
+	}
+	if equal(tagged{"a", []int{1}}, tagged{"a", []int{2}}) {
+		t.Error("structs holding different slices compare equal")
+	}
+	if !equal(tagged{"a", 1}, tagged{"a", 1}) || equal(tagged{"a", 1}, tagged{"a", 2}) {
+		t.Error("structs holding ints compare wrong")
+	}
+
+	arrays := DefaultEquality[[2]any]()
+	if !arrays([2]any{map[string]int{"x": 1}, 2}, [2]any{map[string]int{"x": 1}, 2}) {
+		t.Error("arrays holding equal maps compare unequal")
+	}
+
+	values := DefaultEquality[any]()
+	if !values(tagged{"a", []int{1}}, tagged{"a", []int{1}}) || values(tagged{"a", []int{1}}, nil) {
+		t.Error("interfaces holding structs with slices compare wrong")
+	}
+}
+
+func TestSearchWithInterfaceFields(t *testing.T) {
+	l := NewList(tagged{"a", []int{1}}, tagged{"b", []int{2}}, tagged{"a", []int{1}})
+	if i, ok := l.IndexOf(tagged{"b", []int{2}}); !ok || i != 1 {
+		t.Errorf("IndexOf = %d, %v, want 1, true", i, ok)
+	}
+	if !l.Contains(tagged{"a", []int{1}}) {
+		t.Error("Contains = false, want true")
+	}
+	if RemoveDuplicates(&l); len(l) != 2 {
+		t.Errorf("RemoveDuplicates left %d items, want 2", len(l))
+	}
+}
@@ -0,0 +1,91 @@
+package kl
+
+import (
+	"fmt"
+	"strings"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// TruncatedList formats at most a fixed number of items of a list, see List.Truncated
+type TruncatedList[T any] struct {
+	list  List[T]
+	limit int
+}
+
+// Truncated returns a view of the list that formats only its first limit items,
+// followed by a count of the rest, such as [1 2 3 … 997 more]
+func (l List[T]) Truncated(limit int) TruncatedList[T] {
+	return TruncatedList[T]{list: l, limit: max(limit, 0)}
+}
+
+// Format implements fmt.Formatter.
+// %+v prints one item per line, indented, with the items themselves formatted with %+v.
+// %#v prints Go syntax with the list type, such as kl.List[int]{1, 2, 3}.
+// Every other verb formats the items the same way fmt formats a slice.
+func (l List[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		formatLines(f, verb, l, 0)
+		return
+	}
+	if verb == 'v' && f.Flag('#') {
+		kcodec.FormatGoSyntax(f, l, []T(l))
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), []T(l))
+}
+
+// Format implements fmt.Formatter, formatting like List.Format but stopping after the limit
+func (t TruncatedList[T]) Format(f fmt.State, verb rune) {
+	if len(t.list) <= t.limit {
+		t.list.Format(f, verb)
+		return
+	}
+	more := len(t.list) - t.limit
+	if verb == 'v' && f.Flag('+') {
+		formatLines(f, verb, t.list[:t.limit], more)
+		return
+	}
+	if verb == 'v' && f.Flag('#') {
+		t.list.Format(f, verb)
+		return
+	}
+	format := fmt.FormatString(f, verb)
+	_, _ = f.Write([]byte{'['})
+	for i, item := range t.list[:t.limit] {
+		if i > 0 {
+			_, _ = f.Write([]byte{' '})
+		}
+		fmt.Fprintf(f, format, item)
+	}
+	if t.limit > 0 {
+		_, _ = f.Write([]byte{' '})
+	}
+	fmt.Fprintf(f, "… %d more]", more)
+}
+
+// String returns the truncated list formatted with %v
+func (t TruncatedList[T]) String() string {
+	return fmt.Sprintf("%v", t)
+}
+
+// formatLines writes items one per line between brackets, indenting nested multi-line items
+func formatLines[T any](f fmt.State, verb rune, items List[T], more int) {
+	if len(items) == 0 && more == 0 {
+		_, _ = f.Write([]byte("[]"))
+		return
+	}
+	format := fmt.FormatString(f, verb)
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, item := range items {
+		b.WriteByte('\t')
+		b.WriteString(strings.ReplaceAll(fmt.Sprintf(format, item), "\n", "\n\t"))
+		b.WriteByte('\n')
+	}
+	if more > 0 {
+		fmt.Fprintf(&b, "\t… %d more\n", more)
+	}
+	b.WriteByte(']')
+	_, _ = f.Write([]byte(b.String()))
+}
@@ -0,0 +1,35 @@
+package kl
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatGoSyntax(t *testing.T) {
+	for _, tt := range []struct {
+		value any
+		want  string
+	}{
+		{NewList(1, 2, 3), "kl.List[int]{1, 2, 3}"},
+		{List[int](nil), "kl.List[int](nil)"},
+		{NewList("a"), `kl.List[string]{"a"}`},
+		{NewList(1, 2, 3).Truncated(1), "kl.List[int]{1, 2, 3}"},
+	} {
+		if got := fmt.Sprintf("%#v", tt.value); got != tt.want {
+			t.Errorf("%%#v = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	l := NewList(1, 2, 3)
+	if got := fmt.Sprintf("%v %d %x", l, l, l); got != "[1 2 3] [1 2 3] [1 2 3]" {
+		t.Errorf("Sprintf = %q", got)
+	}
+	if got := fmt.Sprintf("%+v", l); got != "[\n\t1\n\t2\n\t3\n]" {
+		t.Errorf("%%+v = %q", got)
+	}
+	if got := fmt.Sprint(NewList(1, 2, 3, 4).Truncated(2)); got != "[1 2 … 2 more]" {
+		t.Errorf("Truncated = %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package kl
 
 import (
 	"cmp"
+	"reflect"
 	"slices"
 )
 
@@ -56,6 +57,39 @@ func Map[T1 any, T2 any, S1 ~[]T1, S2 ~[]T2](list S1, iteratee func(item T1) T2)
 	return result
 }
 
+// SlidingWindow returns copies of every window of size consecutive elements, starting a new window every step elements.
+// Only full windows are returned, and none if size or step is less than 1.
+func SlidingWindow[T any, S ~[]T](list S, size int, step int) List[S] {
+	result := NewList[S]()
+	if size < 1 || step < 1 {
+		return result
+	}
+	for start := 0; start+size <= len(list); start += step {
+		window := make(S, size)
+		copy(window, list[start:start+size])
+		result.Add(window)
+	}
+	return result
+}
+
+// Interleave takes one element from each slice in turn until all are exhausted
+func Interleave[T any, S ~[]T](lists ...S) S {
+	total, longest := 0, 0
+	for _, list := range lists {
+		total += len(list)
+		longest = max(longest, len(list))
+	}
+	result := make(S, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, list := range lists {
+			if i < len(list) {
+				result = append(result, list[i])
+			}
+		}
+	}
+	return result
+}
+
 // Sort sorts a slice in place
 func Sort[T cmp.Ordered, S ~[]T](list S) {
 	if list == nil {
@@ -75,6 +109,31 @@ func IsSorted[T cmp.Ordered, S ~[]T](list S) bool {
 	return slices.IsSorted[S, T](list)
 }
 
+// SortFunc sorts a slice in place using the comparator
+func SortFunc[T any, S ~[]T](list S, comparator func(a, b T) int) {
+	slices.SortFunc(list, comparator)
+}
+
+// SortStableFunc sorts a slice in place using the comparator, keeping the original order of equal elements
+func SortStableFunc[T any, S ~[]T](list S, comparator func(a, b T) int) {
+	slices.SortStableFunc(list, comparator)
+}
+
+// SortBy sorts a slice in place by the key extracted from each element
+func SortBy[T any, K cmp.Ordered, S ~[]T](list S, key func(T) K) {
+	slices.SortFunc(list, By(key))
+}
+
+// SortStableBy sorts a slice in place by the key extracted from each element, keeping the original order of equal keys
+func SortStableBy[T any, K cmp.Ordered, S ~[]T](list S, key func(T) K) {
+	slices.SortStableFunc(list, By(key))
+}
+
+// IsSortedFunc returns true if the list is sorted according to the comparator
+func IsSortedFunc[T any, S ~[]T](list S, comparator func(a, b T) int) bool {
+	return slices.IsSortedFunc(list, comparator)
+}
+
 // Min returns the smallest element of the slice.
 func Min[T cmp.Ordered, S ~[]T](list S) T {
 	return slices.Min(list)
@@ -85,6 +144,40 @@ func Max[T cmp.Ordered, S ~[]T](list S) T {
 	return slices.Max(list)
 }
 
+// Select returns the k-th smallest element (counting from 0) of the slice in average O(n) time.
+// The slice is reordered in place so that the elements before k are no larger and those after no smaller.
+//
+// Errors: IndexError
+func Select[T cmp.Ordered, S ~[]T](list S, k int) (T, error) {
+	if k < 0 || k >= len(list) {
+		var zero T
+		return zero, NewIndexError(k, len(list))
+	}
+	return quickSelect[T](list, 0, len(list)-1, k), nil
+}
+
+// MinFunc returns the smallest element of the slice according to the comparator, the first one if several are minimal.
+// Panics if the slice is empty, like Min.
+func MinFunc[T any, S ~[]T](list S, comparator func(a, b T) int) T {
+	return slices.MinFunc(list, comparator)
+}
+
+// MaxFunc returns the largest element of the slice according to the comparator, the first one if several are maximal.
+// Panics if the slice is empty, like Max.
+func MaxFunc[T any, S ~[]T](list S, comparator func(a, b T) int) T {
+	return slices.MaxFunc(list, comparator)
+}
+
+// MinBy returns the element of the slice with the smallest key. Panics if the slice is empty, like Min.
+func MinBy[T any, K cmp.Ordered, S ~[]T](list S, key func(T) K) T {
+	return slices.MinFunc(list, By(key))
+}
+
+// MaxBy returns the element of the slice with the largest key. Panics if the slice is empty, like Max.
+func MaxBy[T any, K cmp.Ordered, S ~[]T](list S, key func(T) K) T {
+	return slices.MaxFunc(list, By(key))
+}
+
 // Sum adds up all elements in the slice and returns the total.
 func Sum[T cmp.Ordered, S ~[]T](list S) T {
 	var s T
@@ -95,16 +188,14 @@ func Sum[T cmp.Ordered, S ~[]T](list S) T {
 }
 
 // RemoveDuplicates removes duplicate values from the slice in place, preserving the first occurrence.
+// Types holding interface values, which may not be hashable, are compared pairwise with DefaultEquality.
 func RemoveDuplicates[T comparable, S ~[]T](list *S) {
 	if len(*list) <= 1 {
 		return
 	}
-	result := NewList[T]()
-	for _, v := range *list {
-		if !result.singleContains(v) {
-			result.Add(v)
-		}
+	if holdsInterface(reflect.TypeFor[T]()) {
+		*list = DistinctFunc(*list, DefaultEquality[T]())
+		return
 	}
-	*list = result.ToSlice()
-	return
+	*list = DistinctBy(*list, func(v T) T { return v })
 }
@@ -0,0 +1,99 @@
+package kl
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for _, spread := range []int{1, 3, 1000} {
+		values := make([]int, 500)
+		for i := range values {
+			values[i] = r.IntN(spread)
+		}
+		sorted := slices.Sorted(slices.Values(values))
+		for k := range values {
+			work := slices.Clone(values)
+			got, err := Select(work, k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != sorted[k] {
+				t.Fatalf("spread %d: Select(%d) = %d, want %d", spread, k, got, sorted[k])
+			}
+			for i, v := range work {
+				if (i < k && v > got) || (i > k && v < got) {
+					t.Fatalf("spread %d: Select(%d) left %d at index %d", spread, k, v, i)
+				}
+			}
+		}
+	}
+}
+
+func TestSelectRepeatedValues(t *testing.T) {
+	// Equal values used to make every partition one-sided, taking quadratic time and linear stack depth
+	values := make([]int, 1_000_000)
+	for i := range values {
+		values[i] = i % 2
+	}
+	if got, _ := Select(values, len(values)/2); got != 1 {
+		t.Fatalf("Select = %d, want 1", got)
+	}
+	values = make([]int, 1_000_000)
+	if got, _ := Select(values, 12345); got != 0 {
+		t.Fatalf("Select = %d, want 0", got)
+	}
+}
+
+func TestSelectOutOfRange(t *testing.T) {
+	for _, k := range []int{-1, 3} {
+		if _, err := Select([]int{1, 2, 3}, k); !errors.Is(err, IndexOutOfBoundsError) {
+			t.Errorf("Select(%d) = %v, want IndexOutOfBoundsError", k, err)
+		}
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5}
+	for _, c := range []struct {
+		size, step int
+		want       [][]int
+	}{
+		{2, 1, [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}},
+		{2, 2, [][]int{{1, 2}, {3, 4}}},
+		{3, 2, [][]int{{1, 2, 3}, {3, 4, 5}}},
+		{5, 1, [][]int{{1, 2, 3, 4, 5}}},
+		{6, 1, nil},
+		{0, 1, nil},
+		{2, 0, nil},
+	} {
+		got := SlidingWindow(values, c.size, c.step)
+		if !slices.EqualFunc(got, c.want, slices.Equal) {
+			t.Errorf("SlidingWindow(%d, %d) = %v, want %v", c.size, c.step, got, c.want)
+		}
+	}
+	windows := SlidingWindow(values, 2, 1)
+	windows[0][1] = 99
+	if values[1] != 2 || windows[1][0] != 2 {
+		t.Error("SlidingWindow windows share memory")
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	for _, c := range []struct {
+		lists [][]int
+		want  []int
+	}{
+		{[][]int{{1, 4, 7}, {2, 5}, {3}}, []int{1, 2, 3, 4, 5, 7}},
+		{[][]int{nil, {1, 2}}, []int{1, 2}},
+		{[][]int{{1}}, []int{1}},
+		{nil, []int{}},
+	} {
+		if got := Interleave(c.lists...); !slices.Equal(got, c.want) {
+			t.Errorf("Interleave(%v) = %v, want %v", c.lists, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package kl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DuplicateKeyError is returned by KeyBy when two elements have the same key under the ErrorOnDuplicate policy
+var DuplicateKeyError = errors.New("duplicate key")
+
+// DuplicateKeyPolicy decides which element KeyBy keeps when several have the same key
+type DuplicateKeyPolicy int
+
+const (
+	// KeepFirst keeps the first element with each key
+	KeepFirst DuplicateKeyPolicy = iota
+	// KeepLast keeps the last element with each key
+	KeepLast
+	// ErrorOnDuplicate makes KeyBy fail on the first repeated key
+	ErrorOnDuplicate
+)
+
+// GroupBy groups the elements of the slice by key, in order of each key's first occurrence.
+// Each group keeps the original order of its elements.
+func GroupBy[T any, K comparable, S ~[]T](list S, key func(T) K) OrderedMap[K, List[T]] {
+	result := NewOrderedMap[K, List[T]]()
+	for _, item := range list {
+		k := key(item)
+		group, _ := result.Get(k)
+		result.Set(k, append(group, item))
+	}
+	return result
+}
+
+// CountBy counts the elements of the slice with each key, in order of each key's first occurrence
+func CountBy[T any, K comparable, S ~[]T](list S, key func(T) K) OrderedMap[K, int] {
+	result := NewOrderedMap[K, int]()
+	for _, item := range list {
+		k := key(item)
+		count, _ := result.Get(k)
+		result.Set(k, count+1)
+	}
+	return result
+}
+
+// KeyBy indexes the elements of the slice by key, in order of each key's first occurrence.
+// The policy decides what happens when several elements have the same key.
+//
+// Errors: DuplicateKeyError (only with ErrorOnDuplicate)
+func KeyBy[T any, K comparable, S ~[]T](list S, key func(T) K, policy DuplicateKeyPolicy) (OrderedMap[K, T], error) {
+	result := NewOrderedMap[K, T]()
+	for _, item := range list {
+		k := key(item)
+		if result.Has(k) {
+			switch policy {
+			case KeepFirst:
+				continue
+			case ErrorOnDuplicate:
+				return result, fmt.Errorf("%w: %v", DuplicateKeyError, k)
+			}
+		}
+		result.Set(k, item)
+	}
+	return result, nil
+}
+
+// ChunkBy splits the slice into runs of consecutive elements with the same key
+func ChunkBy[T any, K comparable, S ~[]T](list S, key func(T) K) List[S] {
+	result := NewList[S]()
+	start := 0
+	for i := 1; i <= len(list); i++ {
+		if i == len(list) || key(list[i]) != key(list[start]) {
+			chunk := make(S, i-start)
+			copy(chunk, list[start:i])
+			result.Add(chunk)
+			start = i
+		}
+	}
+	return result
+}
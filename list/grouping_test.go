@@ -0,0 +1,120 @@
+package kl
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	people := List[person]{{"cy", 30}, {"al", 25}, {"bo", 30}, {"di", 25}, {"ed", 40}}
+	groups := GroupBy(people, func(p person) int { return p.age })
+	if got, want := groups.Keys(), []int{30, 25, 40}; !slices.Equal(got, want) {
+		t.Fatalf("GroupBy keys = %v, want %v", got, want)
+	}
+	for age, want := range map[int][]string{30: {"cy", "bo"}, 25: {"al", "di"}, 40: {"ed"}} {
+		if group, _ := groups.Get(age); !slices.Equal(names(group), want) {
+			t.Errorf("GroupBy[%d] = %v, want %v", age, names(group), want)
+		}
+	}
+
+	counts := CountBy([]string{"b", "a", "b", "c", "b", "a"}, func(s string) string { return s })
+	if got, want := counts.String(), "map[b:3 a:2 c:1]"; got != want {
+		t.Errorf("CountBy = %s, want %s", got, want)
+	}
+	if empty := GroupBy([]int(nil), func(int) int { return 0 }); !empty.IsEmpty() {
+		t.Errorf("GroupBy of nil = %v, want empty", &empty)
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	people := List[person]{{"cy", 30}, {"al", 25}, {"bo", 30}}
+	age := func(p person) int { return p.age }
+
+	for _, c := range []struct {
+		policy DuplicateKeyPolicy
+		want   []string
+	}{
+		{KeepFirst, []string{"cy", "al"}},
+		{KeepLast, []string{"bo", "al"}},
+	} {
+		index, err := KeyBy(people, age, c.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(index.Values()); !slices.Equal(got, c.want) {
+			t.Errorf("KeyBy policy %d = %v, want %v", c.policy, got, c.want)
+		}
+	}
+
+	index, err := KeyBy(people, age, ErrorOnDuplicate)
+	if !errors.Is(err, DuplicateKeyError) || err.Error() != "duplicate key: 30" {
+		t.Fatalf("KeyBy ErrorOnDuplicate error = %v, want DuplicateKeyError for 30", err)
+	}
+	if got := names(index.Values()); !slices.Equal(got, []string{"cy", "al"}) {
+		t.Errorf("KeyBy ErrorOnDuplicate kept %v, want the elements before the duplicate", got)
+	}
+	if _, err := KeyBy(people, func(p person) string { return p.name }, ErrorOnDuplicate); err != nil {
+		t.Errorf("KeyBy with unique keys = %v", err)
+	}
+}
+
+func TestChunkBy(t *testing.T) {
+	parity := func(n int) bool { return n%2 == 0 }
+	values := []int{1, 3, 2, 4, 6, 5, 8}
+	chunks := ChunkBy(values, parity)
+	want := [][]int{{1, 3}, {2, 4, 6}, {5}, {8}}
+	if !slices.EqualFunc(chunks, want, slices.Equal) {
+		t.Fatalf("ChunkBy = %v, want %v", chunks, want)
+	}
+	chunks[0][0] = 99
+	if values[0] != 1 {
+		t.Error("ChunkBy shares memory with its input")
+	}
+	if got := ChunkBy([]int{}, parity); len(got) != 0 {
+		t.Errorf("ChunkBy of empty = %v", got)
+	}
+}
+
+func TestOrderedMapOrder(t *testing.T) {
+	var m OrderedMap[string, int]
+	m.Set("a", 1).Set("b", 2).Set("c", 3).Set("a", 10)
+	if got := m.String(); got != "map[a:10 b:2 c:3]" {
+		t.Fatalf("after Set = %s, want existing keys to keep their position", got)
+	}
+
+	if !m.Delete("a") || m.Delete("a") {
+		t.Fatal("Delete did not report the key exactly once")
+	}
+	m.Set("d", 4).Set("a", 1)
+	if got, want := m.Keys(), []string{"b", "c", "d", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("Keys after Delete and re-Set = %v, want %v", got, want)
+	}
+	if got, want := m.Values(), []int{2, 3, 4, 1}; !slices.Equal(got, want) {
+		t.Fatalf("Values = %v, want %v", got, want)
+	}
+	var visited []string
+	for key := range m.All() {
+		visited = append(visited, key)
+	}
+	if !slices.Equal(visited, []string{"b", "c", "d", "a"}) {
+		t.Errorf("All visited %v", visited)
+	}
+
+	c := m.Copy()
+	c.Delete("b")
+	c.Set("b", 0)
+	if m.String() != "map[b:2 c:3 d:4 a:1]" || c.String() != "map[c:3 d:4 a:1 b:0]" {
+		t.Errorf("copy and original share state: %s, %s", m.String(), c.String())
+	}
+	if got := m.ToMap(); len(got) != 4 || got["d"] != 4 {
+		t.Errorf("ToMap = %v", got)
+	}
+	if m.Clear(); !m.IsEmpty() || m.Has("b") || m.Len() != 0 {
+		t.Error("Clear left keys behind")
+	}
+	m.Set("z", 26)
+	if got := m.Keys(); !slices.Equal(got, []string{"z"}) {
+		t.Errorf("Keys after Clear and Set = %v", got)
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
-	"reflect"
+	"math/rand"
 )
 
 // Exported sentinel errors (preferred names)
 var (
 	EmptyListError        = errors.New("list is empty")
 	IndexOutOfBoundsError = errors.New("index out of bounds")
+	InvalidEncodingError  = errors.New("invalid encoding")
 )
 
 // IndexError provides structured context for invalid index operations.
@@ -44,36 +45,41 @@ func NewIndexError(index int, length int) error {
 }
 
 func quickSelect[T cmp.Ordered](arr []T, left, right, k int) T {
-	if left == right {
-		return arr[left]
-	}
-
-	pivotIndex := partition(arr, left, right)
-
-	if k == pivotIndex {
-		return arr[k]
-	} else if k < pivotIndex {
-		return quickSelect[T](arr, left, pivotIndex-1, k)
-	} else {
-		return quickSelect[T](arr, pivotIndex+1, right, k)
+	// Narrow the range to the side holding k instead of recursing, so the depth stays constant
+	for left < right {
+		lt, gt := partition(arr, left, right)
+		switch {
+		case k < lt:
+			right = lt - 1
+		case k > gt:
+			left = gt + 1
+		default:
+			return arr[k]
+		}
 	}
+	return arr[left]
 }
 
-func partition[T cmp.Ordered](arr []T, left, right int) int {
-	pivot := arr[right] // Use the rightmost element as pivot
-	i := left
-
-	// Partition the array around the pivot
-	for j := left; j < right; j++ {
-		if arr[j] <= pivot {
-			arr[i], arr[j] = arr[j], arr[i]
+// partition rearranges arr[left:right+1] around a random pivot into values less than, equal to and greater than it,
+// and returns the bounds of the equal run. Grouping equal values keeps repeated values linear.
+func partition[T cmp.Ordered](arr []T, left, right int) (int, int) {
+	// A random pivot avoids quadratic time on already sorted input
+	pivot := arr[left+rand.Intn(right-left+1)]
+	lt, i, gt := left, left, right
+	for i <= gt {
+		switch c := cmp.Compare(arr[i], pivot); {
+		case c < 0:
+			arr[lt], arr[i] = arr[i], arr[lt]
+			lt++
+			i++
+		case c > 0:
+			arr[i], arr[gt] = arr[gt], arr[i]
+			gt--
+		default:
 			i++
 		}
 	}
-
-	// Move pivot to its final position
-	arr[i], arr[right] = arr[right], arr[i]
-	return i
+	return lt, gt
 }
 
 func formatIndicesReversed(indices List[int]) List[int] {
@@ -128,9 +134,3 @@ func copyList[S ~[]T, T any](list S) S {
 	copy(result, list)
 	return result
 }
-
-func isComparable[T any](value T) bool {
-	valueAny := any(value)
-	t := reflect.TypeOf(valueAny)
-	return t.Comparable()
-}
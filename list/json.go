@@ -0,0 +1,88 @@
+package kl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// JSONDecoder reads the elements of a JSON array from a stream one at a time,
+// so large arrays can be processed without holding the whole document in memory
+type JSONDecoder[T any] struct {
+	decoder *json.Decoder
+	started bool
+	done    bool
+}
+
+// NewJSONDecoder creates a JSONDecoder reading a single JSON array from r
+func NewJSONDecoder[T any](r io.Reader) *JSONDecoder[T] {
+	return &JSONDecoder[T]{decoder: json.NewDecoder(r)}
+}
+
+// Decode returns the next element of the array, or io.EOF once the array is exhausted
+//
+// Errors: InvalidEncodingError, io.EOF, io.ErrUnexpectedEOF
+func (d *JSONDecoder[T]) Decode() (T, error) {
+	var item T
+	if d.done {
+		return item, io.EOF
+	}
+	if !d.started {
+		token, err := d.decoder.Token()
+		if err == io.EOF {
+			// An empty stream holds no array at all, unlike the io.EOF that ends one
+			return item, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return item, err
+		}
+		if token != json.Delim('[') {
+			return item, fmt.Errorf("%w: expected JSON array, got %v", InvalidEncodingError, token)
+		}
+		d.started = true
+	}
+	if !d.decoder.More() {
+		if _, err := d.decoder.Token(); err != nil {
+			return item, err
+		}
+		d.done = true
+		return item, io.EOF
+	}
+	err := d.decoder.Decode(&item)
+	return item, err
+}
+
+// All returns an iterator over the remaining elements, stopping after the first error
+func (d *JSONDecoder[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			item, err := d.Decode()
+			if err == io.EOF {
+				return
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// DecodeInto appends the remaining elements to list as they are read.
+// Elements decoded before an error are kept in the list.
+func (d *JSONDecoder[T]) DecodeInto(list *List[T]) error {
+	for item, err := range d.All() {
+		if err != nil {
+			return err
+		}
+		list.Add(item)
+	}
+	return nil
+}
+
+// DecodeJSON reads a JSON array from r into a new List
+func DecodeJSON[T any](r io.Reader) (List[T], error) {
+	result := NewList[T]()
+	err := NewJSONDecoder[T](r).DecodeInto(&result)
+	return result, err
+}
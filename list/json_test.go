@@ -0,0 +1,92 @@
+package kl
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// brokenReader returns its text and then fails, like a connection dropped mid-document
+type brokenReader struct {
+	text string
+}
+
+var errBroken = errors.New("connection reset")
+
+func (r *brokenReader) Read(p []byte) (int, error) {
+	if r.text == "" {
+		return 0, errBroken
+	}
+	n := copy(p, r.text)
+	r.text = r.text[n:]
+	return n, nil
+}
+
+func TestJSONDecoderStreams(t *testing.T) {
+	// Elements are returned as they arrive, before the rest of the array has been read
+	d := NewJSONDecoder[int](&brokenReader{text: `[1, 2, `})
+	for _, want := range []int{1, 2} {
+		if got, err := d.Decode(); err != nil || got != want {
+			t.Fatalf("Decode = %d, %v, want %d", got, err, want)
+		}
+	}
+	if _, err := d.Decode(); !errors.Is(err, errBroken) {
+		t.Fatalf("Decode after the stream broke = %v, want %v", err, errBroken)
+	}
+
+	list := List[int]{0}
+	err := NewJSONDecoder[int](&brokenReader{text: `[1, 2, `}).DecodeInto(&list)
+	if !errors.Is(err, errBroken) || !slices.Equal(list, []int{0, 1, 2}) {
+		t.Fatalf("DecodeInto = %v, %v, want the elements read before the error", list, err)
+	}
+}
+
+func TestJSONDecoder(t *testing.T) {
+	type point struct{ X, Y int }
+	d := NewJSONDecoder[point](strings.NewReader(`[{"X": 1, "Y": 2}, {"X": 3}]`))
+	var got []point
+	for item, err := range d.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, item)
+	}
+	if want := []point{{1, 2}, {3, 0}}; !slices.Equal(got, want) {
+		t.Fatalf("All = %v, want %v", got, want)
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Fatalf("Decode after the end = %v, want io.EOF", err)
+	}
+
+	// Breaking out of All leaves the remaining elements for the next call
+	d2 := NewJSONDecoder[string](strings.NewReader(`["a", "b", "c"]`))
+	for range d2.All() {
+		break
+	}
+	var rest List[string]
+	if err := d2.DecodeInto(&rest); err != nil || !slices.Equal(rest, []string{"b", "c"}) {
+		t.Fatalf("elements after break = %v, %v, want [b c]", rest, err)
+	}
+
+	for _, c := range []struct {
+		input string
+		want  []int
+		err   error
+	}{
+		{`[]`, []int{}, nil},
+		{` [ 4 ,5 ] `, []int{4, 5}, nil},
+		{`{"a": 1}`, []int{}, InvalidEncodingError},
+		{`7`, []int{}, InvalidEncodingError},
+		{``, []int{}, io.ErrUnexpectedEOF},
+	} {
+		got, err := DecodeJSON[int](strings.NewReader(c.input))
+		if !slices.Equal(got, c.want) || !errors.Is(err, c.err) {
+			t.Errorf("DecodeJSON(%q) = %v, %v, want %v, %v", c.input, got, err, c.want, c.err)
+		}
+	}
+	if got, err := DecodeJSON[int](strings.NewReader(`[1, "x", 3]`)); err == nil || !slices.Equal(got, []int{1}) {
+		t.Errorf("DecodeJSON with a mistyped element = %v, %v, want [1] and an error", got, err)
+	}
+}
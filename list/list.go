@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 )
 
 // List is a generic type alias of []T with useful methods and functions
@@ -209,14 +210,7 @@ func (l *List[T]) Insert(index int, items ...T) error {
 	if !l.validIndexLoose(index) {
 		return NewIndexError(index, l.Len())
 	}
-	if index == len(*l) {
-		l.Add(items...)
-		return nil
-	}
-	prev := (*l)[:index]
-	after := (*l)[index:]
-	*l = append(prev, items...)
-	l.Add(after...)
+	*l = slices.Insert(*l, index, items...)
 	return nil
 }
 
@@ -347,8 +341,52 @@ func (l *List[T]) FindBy(predicate func(T) bool) (T, int, bool) {
 	return zero, -1, false
 }
 
+// SortFunc sorts the list in place using the comparator.
+// Methods cannot take the key's type parameter, so l.SortFunc(By(key)) takes the place of a SortBy method.
+//
+// Supports method chaining
+func (l *List[T]) SortFunc(comparator Comparator[T]) *List[T] {
+	SortFunc(*l, comparator)
+	return l
+}
+
+// SortStableFunc sorts the list in place using the comparator, keeping the original order of equal elements.
+// l.SortStableFunc(By(key)) takes the place of a SortStableBy method.
+//
+// Supports method chaining
+func (l *List[T]) SortStableFunc(comparator Comparator[T]) *List[T] {
+	SortStableFunc(*l, comparator)
+	return l
+}
+
+// IsSortedFunc returns true if the list is sorted according to the comparator
+func (l *List[T]) IsSortedFunc(comparator Comparator[T]) bool {
+	return IsSortedFunc(*l, comparator)
+}
+
+// MinFunc returns the smallest item according to the comparator, or false if the list is empty.
+// l.MinFunc(By(key)) takes the place of a MinBy method.
+func (l *List[T]) MinFunc(comparator Comparator[T]) (T, bool) {
+	if l.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	return MinFunc(*l, comparator), true
+}
+
+// MaxFunc returns the largest item according to the comparator, or false if the list is empty.
+// l.MaxFunc(By(key)) takes the place of a MaxBy method.
+func (l *List[T]) MaxFunc(comparator Comparator[T]) (T, bool) {
+	if l.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	return MaxFunc(*l, comparator), true
+}
+
 // Partition splits the list into a slice of chunks of the given size.
 // If size <= 0, returns a single chunk copy of the list.
+// To split by key or predicate see ChunkBy, GroupBy and kp.PartitionBy.
 func (l *List[T]) Partition(size int) [][]T {
 	if size <= 0 {
 		return [][]T{l.Copy()}
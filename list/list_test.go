@@ -0,0 +1,33 @@
+package kl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInsertWithSpareCapacity(t *testing.T) {
+	l := make(List[int], 3, 10)
+	copy(l, []int{1, 2, 3})
+	if err := l.Insert(1, 9, 8); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 9, 8, 2, 3}; !slices.Equal(l, want) {
+		t.Fatalf("Insert = %v, want %v", l, want)
+	}
+}
+
+func TestInsertAtEnds(t *testing.T) {
+	l := NewList(2)
+	if err := l.Insert(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Insert(2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(l, want) {
+		t.Fatalf("Insert = %v, want %v", l, want)
+	}
+	if err := l.Insert(5, 4); err == nil {
+		t.Fatal("Insert past the end returned nil error")
+	}
+}
@@ -0,0 +1,309 @@
+package kl
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ChangeKind is the kind of change a Change describes
+type ChangeKind int
+
+const (
+	// ChangeAdded marks an item added at Index, with the value New
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved marks the item Old removed from Index
+	ChangeRemoved
+	// ChangeSet marks the item at Index replaced, from Old to New
+	ChangeSet
+	// ChangeMoved marks the item Old moved from Index to ToIndex, trading places with the item New moved from ToIndex to Index
+	ChangeMoved
+	// ChangeCleared marks every item removed at once, the removed items are in Cleared
+	ChangeCleared
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeSet:
+		return "set"
+	case ChangeMoved:
+		return "moved"
+	case ChangeCleared:
+		return "cleared"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change describes one change to an ObservableList.
+// Changes are listed in the order they were applied, so each Index refers to the list as it was just before that change,
+// and replaying them in order on a copy of the list reproduces it. Swap, Shuffle and Reverse are reported as Moved swaps.
+type Change[T any] struct {
+	Kind    ChangeKind
+	Index   int
+	ToIndex int
+	Old     T
+	New     T
+	Cleared List[T]
+}
+
+// Subscription is returned by ObservableList.Subscribe and stops the notifications when unsubscribed
+type Subscription struct {
+	unsubscribe func()
+}
+
+// Unsubscribe stops notifications to the subscriber. Calling it more than once has no effect.
+func (s Subscription) Unsubscribe() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+// ObservableList wraps a List and notifies subscribers of every change made through its mutating methods.
+// Each call to a mutating method notifies subscribers once with all of its changes,
+// and Batch groups the changes of several calls into a single notification.
+// Like List, it is not safe for concurrent use.
+type ObservableList[T any] struct {
+	items       List[T]
+	subscribers OrderedMap[int, func(List[Change[T]])]
+	nextID      int
+	depth       int
+	pending     List[Change[T]]
+}
+
+// NewObservableList creates a new ObservableList holding a copy of items
+func NewObservableList[T any](items ...T) *ObservableList[T] {
+	return &ObservableList[T]{items: copyList(List[T](items))}
+}
+
+// Subscribe registers f to be called with the changes of every mutating call or Batch that changes the list.
+// Subscribers are called in the order they subscribed and must not modify the changes they are given.
+func (o *ObservableList[T]) Subscribe(f func(changes List[Change[T]])) Subscription {
+	id := o.nextID
+	o.nextID++
+	o.subscribers.Set(id, f)
+	return Subscription{unsubscribe: func() { o.subscribers.Delete(id) }}
+}
+
+// Batch runs f and notifies subscribers once with every change made during it. Batches may be nested.
+func (o *ObservableList[T]) Batch(f func()) {
+	o.begin()
+	defer o.end()
+	f()
+}
+
+// Len returns the number of items in the list
+func (o *ObservableList[T]) Len() int {
+	return len(o.items)
+}
+
+// IsEmpty returns true if the list is empty
+func (o *ObservableList[T]) IsEmpty() bool {
+	return len(o.items) == 0
+}
+
+// Get the item at index i, or false if the index is out of bounds
+func (o *ObservableList[T]) Get(i int) (T, bool) {
+	return o.items.Get(i)
+}
+
+// ToList returns a copy of the items
+func (o *ObservableList[T]) ToList() List[T] {
+	return o.items.Copy()
+}
+
+// String returns the string representation of the items
+func (o *ObservableList[T]) String() string {
+	return o.items.String()
+}
+
+// Add items to the end of the list. Supports method chaining.
+func (o *ObservableList[T]) Add(items ...T) *ObservableList[T] {
+	o.begin()
+	defer o.end()
+	for _, item := range items {
+		o.emit(Change[T]{Kind: ChangeAdded, Index: len(o.items), New: item})
+		o.items.Add(item)
+	}
+	return o
+}
+
+// Insert items at the specified index
+//
+// Errors: IndexError
+func (o *ObservableList[T]) Insert(index int, items ...T) error {
+	if err := o.items.Insert(index, items...); err != nil {
+		return err
+	}
+	o.begin()
+	defer o.end()
+	for i, item := range items {
+		o.emit(Change[T]{Kind: ChangeAdded, Index: index + i, New: item})
+	}
+	return nil
+}
+
+// Remove the items at indices, gives up and returns an error if any of the indices are out of bounds
+func (o *ObservableList[T]) Remove(indices ...int) error {
+	for _, index := range indices {
+		if !o.items.ValidIndex(index) {
+			return NewIndexError(index, o.items.Len())
+		}
+	}
+	o.begin()
+	defer o.end()
+	for _, index := range formatIndicesReversed(copyList(List[int](indices))) {
+		o.removeAt(index)
+	}
+	return nil
+}
+
+// Pop removes and returns the last item in the list, or the item at index i if specified
+func (o *ObservableList[T]) Pop(i ...int) (T, bool) {
+	index := len(o.items) - 1
+	if len(i) > 0 {
+		index = i[0]
+	}
+	if !o.items.ValidIndex(index) {
+		var zero T
+		return zero, false
+	}
+	o.begin()
+	defer o.end()
+	return o.removeAt(index), true
+}
+
+// Set replaces the element at the specified index with value
+//
+// Errors: IndexError
+func (o *ObservableList[T]) Set(index int, value T) error {
+	old, ok := o.items.Get(index)
+	if !ok {
+		return NewIndexError(index, o.items.Len())
+	}
+	o.begin()
+	defer o.end()
+	o.items[index] = value
+	o.emit(Change[T]{Kind: ChangeSet, Index: index, Old: old, New: value})
+	return nil
+}
+
+// Swap switches the elements at two indices
+//
+// Errors: IndexError
+func (o *ObservableList[T]) Swap(i, j int) error {
+	if !o.items.ValidIndex(i) {
+		return NewIndexError(i, o.items.Len())
+	}
+	if !o.items.ValidIndex(j) {
+		return NewIndexError(j, o.items.Len())
+	}
+	o.begin()
+	defer o.end()
+	o.swap(i, j)
+	return nil
+}
+
+// Filter keeps elements for which predicate returns true. Supports method chaining.
+func (o *ObservableList[T]) Filter(predicate func(T) bool) *ObservableList[T] {
+	o.begin()
+	defer o.end()
+	for index := len(o.items) - 1; index >= 0; index-- {
+		if !predicate(o.items[index]) {
+			o.removeAt(index)
+		}
+	}
+	return o
+}
+
+// Map applies f to each element in place, reporting a Set change for every element. Supports method chaining.
+func (o *ObservableList[T]) Map(f func(T) T) *ObservableList[T] {
+	return o.MapFunc(f, func(a, b T) bool { return false })
+}
+
+// MapFunc applies f to each element in place, reporting a Set change only for the elements
+// whose new value is not equal to the old one. Supports method chaining.
+func (o *ObservableList[T]) MapFunc(f func(T) T, equal Equality[T]) *ObservableList[T] {
+	o.begin()
+	defer o.end()
+	for index, old := range o.items {
+		value := f(old)
+		o.items[index] = value
+		if !equal(old, value) {
+			o.emit(Change[T]{Kind: ChangeSet, Index: index, Old: old, New: value})
+		}
+	}
+	return o
+}
+
+// Shuffle randomizes the order of the list. Supports method chaining.
+func (o *ObservableList[T]) Shuffle() *ObservableList[T] {
+	o.begin()
+	defer o.end()
+	for i := len(o.items) - 1; i > 0; i-- {
+		o.swap(i, rand.Intn(i+1))
+	}
+	return o
+}
+
+// Reverse reverses the order of the list. Supports method chaining.
+func (o *ObservableList[T]) Reverse() *ObservableList[T] {
+	o.begin()
+	defer o.end()
+	for i, j := 0, len(o.items)-1; i < j; i, j = i+1, j-1 {
+		o.swap(i, j)
+	}
+	return o
+}
+
+// Clear the list. Supports method chaining.
+func (o *ObservableList[T]) Clear() *ObservableList[T] {
+	if len(o.items) == 0 {
+		return o
+	}
+	o.begin()
+	defer o.end()
+	o.emit(Change[T]{Kind: ChangeCleared, Cleared: o.items})
+	o.items = List[T]{}
+	return o
+}
+
+// swap exchanges the items at i and j, reporting a Moved change unless i == j
+func (o *ObservableList[T]) swap(i, j int) {
+	if i == j {
+		return
+	}
+	o.items[i], o.items[j] = o.items[j], o.items[i]
+	o.emit(Change[T]{Kind: ChangeMoved, Index: i, ToIndex: j, Old: o.items[j], New: o.items[i]})
+}
+
+func (o *ObservableList[T]) removeAt(index int) T {
+	old := o.items[index]
+	_ = o.items.Remove(index)
+	o.emit(Change[T]{Kind: ChangeRemoved, Index: index, Old: old})
+	return old
+}
+
+func (o *ObservableList[T]) begin() {
+	o.depth++
+}
+
+func (o *ObservableList[T]) emit(change Change[T]) {
+	o.pending.Add(change)
+}
+
+// end closes a batch, notifying subscribers when the outermost one ends
+func (o *ObservableList[T]) end() {
+	o.depth--
+	if o.depth > 0 || len(o.pending) == 0 {
+		return
+	}
+	changes := o.pending
+	o.pending = nil
+	for _, f := range o.subscribers.Values() {
+		f(changes)
+	}
+}
@@ -0,0 +1,222 @@
+package kl
+
+import (
+	"slices"
+	"testing"
+)
+
+// replay applies changes in order to items, checking that every Old value matches what it replaces
+func replay(t *testing.T, items List[int], changes List[Change[int]]) List[int] {
+	t.Helper()
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAdded:
+			items = slices.Insert(items, c.Index, c.New)
+		case ChangeRemoved:
+			if items[c.Index] != c.Old {
+				t.Fatalf("removed %d at %d, but the replayed list holds %d", c.Old, c.Index, items[c.Index])
+			}
+			items = slices.Delete(items, c.Index, c.Index+1)
+		case ChangeSet:
+			if items[c.Index] != c.Old {
+				t.Fatalf("set %d at %d, but the replayed list holds %d", c.Old, c.Index, items[c.Index])
+			}
+			items[c.Index] = c.New
+		case ChangeMoved:
+			if items[c.Index] != c.Old || items[c.ToIndex] != c.New {
+				t.Fatalf("moved %d and %d, but the replayed list holds %d and %d", c.Old, c.New, items[c.Index], items[c.ToIndex])
+			}
+			items[c.Index], items[c.ToIndex] = items[c.ToIndex], items[c.Index]
+		case ChangeCleared:
+			if !slices.Equal(items, c.Cleared) {
+				t.Fatalf("cleared %v, but the replayed list holds %v", c.Cleared, items)
+			}
+			items = List[int]{}
+		}
+	}
+	return items
+}
+
+// observe subscribes a replaying copy to o and returns a function that checks it against o
+// and the number of notifications since the last check
+func observe(t *testing.T, o *ObservableList[int]) func(name string, notifications int) {
+	t.Helper()
+	replica := o.ToList()
+	received := 0
+	o.Subscribe(func(changes List[Change[int]]) {
+		received++
+		replica = replay(t, replica, changes)
+	})
+	return func(name string, notifications int) {
+		t.Helper()
+		if received != notifications {
+			t.Fatalf("%s: %d notifications, want %d", name, received, notifications)
+		}
+		if !slices.Equal(replica, o.ToList()) {
+			t.Fatalf("%s: replayed %v, want %v", name, replica, o.ToList())
+		}
+		received = 0
+	}
+}
+
+func TestObservableListReplay(t *testing.T) {
+	o := NewObservableList(1, 2, 3, 4, 5)
+	check := observe(t, o)
+
+	o.Add(6, 7)
+	check("Add", 1)
+	if err := o.Insert(1, 10, 11); err != nil {
+		t.Fatal(err)
+	}
+	check("Insert", 1)
+	if err := o.Remove(5, 0, 5, 3); err != nil {
+		t.Fatal(err)
+	}
+	check("Remove unsorted with duplicates", 1)
+	if _, ok := o.Pop(); !ok {
+		t.Fatal("Pop returned false")
+	}
+	check("Pop", 1)
+	if _, ok := o.Pop(0); !ok {
+		t.Fatal("Pop(0) returned false")
+	}
+	check("Pop(0)", 1)
+	if err := o.Set(1, 42); err != nil {
+		t.Fatal(err)
+	}
+	check("Set", 1)
+	o.Add(8, 9, 12, 13)
+	check("Add", 1)
+	if err := o.Swap(0, o.Len()-1); err != nil {
+		t.Fatal(err)
+	}
+	check("Swap", 1)
+	o.Filter(func(v int) bool { return v%2 == 0 })
+	check("Filter", 1)
+	o.Map(func(v int) int { return v * 3 })
+	check("Map", 1)
+	o.MapFunc(func(v int) int { return min(v, 20) }, func(a, b int) bool { return a == b })
+	check("MapFunc", 1)
+	o.Add(1, 2, 3, 4, 5, 6, 7)
+	check("Add", 1)
+	o.Shuffle()
+	check("Shuffle", 1)
+	o.Reverse()
+	check("Reverse", 1)
+	o.Clear()
+	check("Clear", 1)
+	if !o.IsEmpty() {
+		t.Fatalf("Clear left %v", o.ToList())
+	}
+}
+
+func TestObservableListMapReportsEverySet(t *testing.T) {
+	o := NewObservableList(1, 2, 3)
+	var changes List[Change[int]]
+	o.Subscribe(func(c List[Change[int]]) { changes = c })
+
+	// Map reports every element, even unchanged ones
+	o.Map(func(v int) int { return v })
+	if len(changes) != 3 {
+		t.Fatalf("Map reported %d changes, want 3", len(changes))
+	}
+	for i, c := range changes {
+		if c.Kind != ChangeSet || c.Index != i || c.Old != c.New {
+			t.Fatalf("Map change %d = %+v", i, c)
+		}
+	}
+
+	// MapFunc only reports elements that changed
+	changes = nil
+	o.MapFunc(func(v int) int { return min(v, 2) }, func(a, b int) bool { return a == b })
+	if len(changes) != 1 || changes[0].Index != 2 || changes[0].Old != 3 || changes[0].New != 2 {
+		t.Fatalf("MapFunc reported %+v, want one Set of index 2 from 3 to 2", changes)
+	}
+	changes = nil
+	o.MapFunc(func(v int) int { return v }, func(a, b int) bool { return a == b })
+	if changes != nil {
+		t.Fatalf("MapFunc without changes notified %+v", changes)
+	}
+}
+
+func TestObservableListBatch(t *testing.T) {
+	o := NewObservableList(1, 2, 3)
+	check := observe(t, o)
+	notified := false
+	o.Subscribe(func(List[Change[int]]) { notified = true })
+
+	o.Batch(func() {
+		o.Add(4)
+		o.Batch(func() {
+			_ = o.Set(0, 10)
+			o.Reverse()
+		})
+		if notified {
+			t.Fatal("the inner Batch notified before the outer one ended")
+		}
+		_ = o.Remove(1)
+	})
+	check("nested Batch", 1)
+
+	o.Batch(func() {})
+	check("empty Batch", 0)
+}
+
+func TestObservableListNoOpCallsDoNotNotify(t *testing.T) {
+	o := NewObservableList[int]()
+	check := observe(t, o)
+
+	o.Clear()
+	o.Add()
+	o.Filter(func(int) bool { return false })
+	o.Reverse()
+	o.Shuffle()
+	o.Map(func(v int) int { return v })
+	if _, ok := o.Pop(); ok {
+		t.Fatal("Pop of empty list returned true")
+	}
+	check("calls on an empty list", 0)
+
+	o.Add(1, 2)
+	check("Add", 1)
+	if err := o.Swap(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	_ = o.Remove()
+	_ = o.Insert(1)
+	o.Filter(func(int) bool { return true })
+	check("calls that change nothing", 0)
+
+	if err := o.Swap(0, 5); err == nil {
+		t.Fatal("Swap out of bounds returned nil error")
+	}
+	if err := o.Remove(0, 7); err == nil {
+		t.Fatal("Remove out of bounds returned nil error")
+	}
+	if err := o.Set(-1, 0); err == nil {
+		t.Fatal("Set out of bounds returned nil error")
+	}
+	if err := o.Insert(9, 1); err == nil {
+		t.Fatal("Insert out of bounds returned nil error")
+	}
+	check("failed calls", 0)
+}
+
+func TestObservableListUnsubscribe(t *testing.T) {
+	o := NewObservableList[int]()
+	var calls []string
+	var first Subscription
+	first = o.Subscribe(func(List[Change[int]]) {
+		calls = append(calls, "first")
+		first.Unsubscribe()
+	})
+	o.Subscribe(func(List[Change[int]]) { calls = append(calls, "second") })
+
+	o.Add(1)
+	o.Add(2)
+	if want := []string{"first", "second", "second"}; !slices.Equal(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	first.Unsubscribe()
+	Subscription{}.Unsubscribe()
+}
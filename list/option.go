@@ -0,0 +1,55 @@
+package kl
+
+import ko "github.com/KeylimeVI/keylime-go/option"
+
+// GetOpt is Get returning an Option: the item at index i (the last item if no index is given), or None
+func (l *List[T]) GetOpt(i ...int) ko.Option[T] {
+	return ko.OptionOf(l.Get(i...))
+}
+
+// PopOpt is Pop returning an Option: removes and returns the last item, or the item at index i, or None
+func (l *List[T]) PopOpt(i ...int) ko.Option[T] {
+	return ko.OptionOf(l.Pop(i...))
+}
+
+// FirstOpt returns the first item, or None if the list is empty
+func (l *List[T]) FirstOpt() ko.Option[T] {
+	return ko.OptionOf(l.Get(0))
+}
+
+// LastOpt returns the last item, or None if the list is empty
+func (l *List[T]) LastOpt() ko.Option[T] {
+	return ko.OptionOf(l.Get())
+}
+
+// FindOpt returns the first item for which predicate returns true, or None
+func (l *List[T]) FindOpt(predicate func(T) bool) ko.Option[T] {
+	item, _, ok := l.FindBy(predicate)
+	return ko.OptionOf(item, ok)
+}
+
+// CollectOptions returns Some list of the values if every option holds one, otherwise None
+func CollectOptions[T any, S ~[]ko.Option[T]](options S) ko.Option[List[T]] {
+	result := make(List[T], 0, len(options))
+	for _, option := range options {
+		value, ok := option.Get()
+		if !ok {
+			return ko.None[List[T]]()
+		}
+		result = append(result, value)
+	}
+	return ko.Some(result)
+}
+
+// CollectResults returns an Ok list of the values if every result is Ok, otherwise the first Err
+func CollectResults[T any, S ~[]ko.Result[T]](results S) ko.Result[List[T]] {
+	result := make(List[T], 0, len(results))
+	for _, r := range results {
+		value, err := r.Get()
+		if err != nil {
+			return ko.Err[List[T]](err)
+		}
+		result = append(result, value)
+	}
+	return ko.Ok(result)
+}
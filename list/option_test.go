@@ -0,0 +1,47 @@
+package kl
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	ko "github.com/KeylimeVI/keylime-go/option"
+)
+
+func TestListOptions(t *testing.T) {
+	l := List[int]{3, 6, 9}
+	if l.FirstOpt() != ko.Some(3) || l.LastOpt() != ko.Some(9) || l.GetOpt(1) != ko.Some(6) || l.GetOpt(5).IsSome() {
+		t.Error("FirstOpt, LastOpt or GetOpt returned the wrong option")
+	}
+	if l.FindOpt(func(n int) bool { return n > 4 }) != ko.Some(6) || l.FindOpt(func(n int) bool { return n > 9 }).IsSome() {
+		t.Error("FindOpt returned the wrong option")
+	}
+	if l.PopOpt() != ko.Some(9) || l.PopOpt(0) != ko.Some(3) || !slices.Equal(l, []int{6}) {
+		t.Errorf("PopOpt left %v, want [6]", l)
+	}
+	empty := List[int]{}
+	if empty.FirstOpt().IsSome() || empty.LastOpt().IsSome() || empty.PopOpt().IsSome() {
+		t.Error("an empty list returned Some")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	if got := CollectOptions([]ko.Option[int]{ko.Some(1), ko.Some(2)}); !slices.Equal(got.Unwrap(), []int{1, 2}) {
+		t.Errorf("CollectOptions of Somes = %v", got)
+	}
+	if got := CollectOptions([]ko.Option[int]{ko.Some(1), ko.None[int]()}); got.IsSome() {
+		t.Errorf("CollectOptions with a None = %v", got)
+	}
+
+	first, second := errors.New("first"), errors.New("second")
+	if got := CollectResults([]ko.Result[int]{ko.Ok(1), ko.Ok(2)}); !slices.Equal(got.Unwrap(), []int{1, 2}) {
+		t.Errorf("CollectResults of Oks = %v", got)
+	}
+	results := []ko.Result[int]{ko.Ok(1), ko.Err[int](first), ko.Ok(3), ko.Err[int](second)}
+	if got := CollectResults(results); got.Err() != first {
+		t.Errorf("CollectResults = %v, want the first error", got)
+	}
+	if got := CollectResults([]ko.Result[int](nil)); !got.IsOk() || len(got.Unwrap()) != 0 {
+		t.Errorf("CollectResults of nil = %v, want an empty Ok list", got)
+	}
+}
@@ -0,0 +1,142 @@
+package kl
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// OrderedMap is a map that remembers the order its keys were first inserted in.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	keys   List[K]
+	values map[K]V
+}
+
+// NewOrderedMap creates a new empty OrderedMap
+func NewOrderedMap[K comparable, V any]() OrderedMap[K, V] {
+	return OrderedMap[K, V]{values: map[K]V{}}
+}
+
+// Get returns the value stored for key, or false if the key is not present
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Set stores value for key. A new key is placed last, an existing key keeps its position.
+//
+// Supports method chaining
+func (m *OrderedMap[K, V]) Set(key K, value V) *OrderedMap[K, V] {
+	if m.values == nil {
+		m.values = map[K]V{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys.Add(key)
+	}
+	m.values[key] = value
+	return m
+}
+
+// Delete removes key from the map, returns false if the key was not present
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	if _, ok := m.values[key]; !ok {
+		return false
+	}
+	delete(m.values, key)
+	index, _ := m.keys.IndexOfFunc(func(k K) bool { return k == key })
+	_ = m.keys.Remove(index)
+	return true
+}
+
+// Has returns true if the map contains all of the keys
+func (m *OrderedMap[K, V]) Has(keys ...K) bool {
+	for _, key := range keys {
+		if _, ok := m.values[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of keys in the map
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// IsEmpty returns true if the map is empty
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return len(m.keys) == 0
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap[K, V]) Keys() List[K] {
+	return m.keys.Copy()
+}
+
+// Values returns the values in key insertion order
+func (m *OrderedMap[K, V]) Values() List[V] {
+	result := make(List[V], len(m.keys))
+	for i, key := range m.keys {
+		result[i] = m.values[key]
+	}
+	return result
+}
+
+// All returns an iterator over the keys and values in insertion order
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range m.keys {
+			if !yield(key, m.values[key]) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls f for each key and value in insertion order. Supports method chaining.
+func (m *OrderedMap[K, V]) ForEach(f func(key K, value V)) *OrderedMap[K, V] {
+	for _, key := range m.keys {
+		f(key, m.values[key])
+	}
+	return m
+}
+
+// Clear all keys from the map
+func (m *OrderedMap[K, V]) Clear() *OrderedMap[K, V] {
+	m.keys = nil
+	clear(m.values)
+	return m
+}
+
+// Copy returns a new shallow copy of the map
+func (m *OrderedMap[K, V]) Copy() OrderedMap[K, V] {
+	result := OrderedMap[K, V]{keys: m.keys.Copy(), values: make(map[K]V, len(m.values))}
+	for key, value := range m.values {
+		result.values[key] = value
+	}
+	return result
+}
+
+// ToMap converts the ordered map to a builtin map
+func (m *OrderedMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, len(m.values))
+	for key, value := range m.values {
+		result[key] = value
+	}
+	return result
+}
+
+// String returns the string representation of the map in insertion order
+func (m *OrderedMap[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v:%v", key, m.values[key])
+	}
+	b.WriteByte(']')
+	return b.String()
+}
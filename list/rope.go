@@ -0,0 +1,478 @@
+package kl
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand"
+	"strings"
+	"unicode/utf8"
+)
+
+// ropeChunkSize is the number of items stored together in a leaf when building a rope
+const ropeChunkSize = 64
+
+// Rope is a sequence of T stored as a balanced tree of chunks.
+// Insert, Delete, Slice, Concat, At and Index take O(log n) time, unlike List which copies on every edit.
+// Edits merge the chunks next to them while they fit in one chunk, so many small edits do not fragment the rope.
+//
+// Ropes are persistent: a copy of a Rope value is a cheap snapshot that is not affected by edits to the original.
+type Rope[T any] struct {
+	root *ropeNode[T]
+}
+
+// ropeNode is an immutable treap node ordered by position and heap-ordered by priority
+type ropeNode[T any] struct {
+	left     *ropeNode[T]
+	right    *ropeNode[T]
+	chunk    []T
+	size     int
+	priority uint64
+}
+
+// NewRope creates a new Rope with the specified items
+func NewRope[T any](items ...T) Rope[T] {
+	return Rope[T]{root: ropeBuild(items)}
+}
+
+// RopeFromList creates a new Rope with the items of list
+func RopeFromList[T any](list List[T]) Rope[T] {
+	return NewRope(list...)
+}
+
+// Len returns the number of items in the rope
+func (r *Rope[T]) Len() int {
+	return r.root.len()
+}
+
+// IsEmpty returns true if the rope is empty
+func (r *Rope[T]) IsEmpty() bool {
+	return r.root == nil
+}
+
+// ValidIndex checks if the index is within the rope bounds
+func (r *Rope[T]) ValidIndex(index int) bool {
+	return index >= 0 && index < r.Len()
+}
+
+// At returns the item at index i, or false if the index is out of bounds
+func (r *Rope[T]) At(i int) (T, bool) {
+	if !r.ValidIndex(i) {
+		var zero T
+		return zero, false
+	}
+	n := r.root
+	for {
+		leftSize := n.left.len()
+		switch {
+		case i < leftSize:
+			n = n.left
+		case i < leftSize+len(n.chunk):
+			return n.chunk[i-leftSize], true
+		default:
+			i -= leftSize + len(n.chunk)
+			n = n.right
+		}
+	}
+}
+
+// Index returns the item at index i in O(log n), or false if the index is out of bounds. It is the same as At.
+func (r *Rope[T]) Index(i int) (T, bool) {
+	return r.At(i)
+}
+
+// Add items to the end of the rope
+//
+// Supports method chaining
+func (r *Rope[T]) Add(items ...T) *Rope[T] {
+	r.root = ropeJoin(r.root, items, nil)
+	return r
+}
+
+// Insert items at the specified index
+//
+// Errors: IndexError
+func (r *Rope[T]) Insert(index int, items ...T) error {
+	if index < 0 || index > r.Len() {
+		return NewIndexError(index, r.Len())
+	}
+	left, right := ropeSplit(r.root, index)
+	r.root = ropeJoin(left, items, right)
+	return nil
+}
+
+// Delete removes the items from start (inclusive) to end (exclusive)
+//
+// Errors: IndexError
+func (r *Rope[T]) Delete(start int, end int) error {
+	if err := r.checkRange(start, end); err != nil {
+		return err
+	}
+	left, rest := ropeSplit(r.root, start)
+	_, right := ropeSplit(rest, end-start)
+	r.root = ropeJoin(left, nil, right)
+	return nil
+}
+
+// Set replaces the item at the specified index with value
+//
+// Errors: IndexError
+func (r *Rope[T]) Set(index int, value T) error {
+	if !r.ValidIndex(index) {
+		return NewIndexError(index, r.Len())
+	}
+	r.root = r.root.set(index, value)
+	return nil
+}
+
+// Slice returns the items from start (inclusive) to end (exclusive) as a new rope, sharing structure with r.
+//
+// Errors: IndexError
+func (r *Rope[T]) Slice(start int, end int) (Rope[T], error) {
+	if err := r.checkRange(start, end); err != nil {
+		return Rope[T]{}, err
+	}
+	_, rest := ropeSplit(r.root, start)
+	middle, _ := ropeSplit(rest, end-start)
+	return Rope[T]{root: middle}, nil
+}
+
+// Concat appends the items of the other ropes to the rope
+//
+// Supports method chaining
+func (r *Rope[T]) Concat(others ...Rope[T]) *Rope[T] {
+	for _, other := range others {
+		r.root = ropeJoin(r.root, nil, other.root)
+	}
+	return r
+}
+
+// Clear the rope
+//
+// Supports method chaining
+func (r *Rope[T]) Clear() *Rope[T] {
+	r.root = nil
+	return r
+}
+
+// IndexFunc returns the index of the first item for which predicate returns true, or (-1, false).
+// Unlike the positional operations, Index included, this scans the rope in O(n).
+func (r *Rope[T]) IndexFunc(predicate func(T) bool) (int, bool) {
+	for i, item := range r.All() {
+		if predicate(item) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// ForEach calls f for each item in the rope. Supports method chaining.
+func (r *Rope[T]) ForEach(f func(T)) *Rope[T] {
+	for item := range r.Values() {
+		f(item)
+	}
+	return r
+}
+
+// Values returns an iterator over the items of the rope in order
+func (r *Rope[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range r.All() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the indices and items of the rope in order
+func (r *Rope[T]) All() iter.Seq2[int, T] {
+	root := r.root
+	return func(yield func(int, T) bool) {
+		index := 0
+		var stack []*ropeNode[T]
+		n := root
+		for n != nil || len(stack) > 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.left
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, item := range n.chunk {
+				if !yield(index, item) {
+					return
+				}
+				index++
+			}
+			n = n.right
+		}
+	}
+}
+
+// ToList converts the rope to a List
+func (r *Rope[T]) ToList() List[T] {
+	result := make(List[T], 0, r.Len())
+	for item := range r.Values() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// ToSlice converts the rope to a native slice
+func (r *Rope[T]) ToSlice() []T {
+	return r.ToList()
+}
+
+// String returns the string representation of the rope
+func (r *Rope[T]) String() string {
+	return fmt.Sprintf("%v", []T(r.ToList()))
+}
+
+func (r *Rope[T]) checkRange(start int, end int) error {
+	if start < 0 {
+		return NewIndexError(start, r.Len())
+	}
+	if end > r.Len() {
+		return NewIndexError(end, r.Len())
+	}
+	if start > end {
+		return errors.New("rope: start must be less than or equal to end")
+	}
+	return nil
+}
+
+// StringRope is a Rope of runes for editing large texts, indexed by rune rather than by byte
+type StringRope struct {
+	runes Rope[rune]
+}
+
+// NewStringRope creates a new StringRope holding s
+func NewStringRope(s string) StringRope {
+	return StringRope{runes: NewRope([]rune(s)...)}
+}
+
+// Len returns the number of runes in the rope
+func (s *StringRope) Len() int {
+	return s.runes.Len()
+}
+
+// IsEmpty returns true if the rope is empty
+func (s *StringRope) IsEmpty() bool {
+	return s.runes.IsEmpty()
+}
+
+// At returns the rune at index i, or false if the index is out of bounds
+func (s *StringRope) At(i int) (rune, bool) {
+	return s.runes.At(i)
+}
+
+// Index returns the rune at index i in O(log n), or false if the index is out of bounds. It is the same as At.
+func (s *StringRope) Index(i int) (rune, bool) {
+	return s.runes.At(i)
+}
+
+// Add appends text to the end of the rope
+//
+// Supports method chaining
+func (s *StringRope) Add(text string) *StringRope {
+	s.runes.Add([]rune(text)...)
+	return s
+}
+
+// Insert text at the specified rune index
+//
+// Errors: IndexError
+func (s *StringRope) Insert(index int, text string) error {
+	return s.runes.Insert(index, []rune(text)...)
+}
+
+// Delete removes the runes from start (inclusive) to end (exclusive)
+//
+// Errors: IndexError
+func (s *StringRope) Delete(start int, end int) error {
+	return s.runes.Delete(start, end)
+}
+
+// Slice returns the runes from start (inclusive) to end (exclusive) as a new rope
+//
+// Errors: IndexError
+func (s *StringRope) Slice(start int, end int) (StringRope, error) {
+	runes, err := s.runes.Slice(start, end)
+	return StringRope{runes: runes}, err
+}
+
+// Concat appends the text of the other ropes to the rope
+//
+// Supports method chaining
+func (s *StringRope) Concat(others ...StringRope) *StringRope {
+	for _, other := range others {
+		s.runes.Concat(other.runes)
+	}
+	return s
+}
+
+// IndexOf returns the rune index of the first occurrence of substr, or (-1, false) if it is not present.
+// It scans the rope once in O(n) without building the whole string.
+func (s *StringRope) IndexOf(substr string) (int, bool) {
+	pattern := []rune(substr)
+	if len(pattern) == 0 {
+		return 0, true
+	}
+	// fallback[i] is the length of the longest proper prefix of pattern[:i+1] that is also its suffix
+	fallback := make([]int, len(pattern))
+	for i, k := 1, 0; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = fallback[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		fallback[i] = k
+	}
+	matched := 0
+	for i, r := range s.runes.All() {
+		for matched > 0 && r != pattern[matched] {
+			matched = fallback[matched-1]
+		}
+		if r == pattern[matched] {
+			matched++
+		}
+		if matched == len(pattern) {
+			return i - len(pattern) + 1, true
+		}
+	}
+	return -1, false
+}
+
+// String returns the text held by the rope
+func (s *StringRope) String() string {
+	size := 0
+	for r := range s.runes.Values() {
+		if n := utf8.RuneLen(r); n > 0 {
+			size += n
+		} else {
+			size += utf8.RuneLen(utf8.RuneError)
+		}
+	}
+	var b strings.Builder
+	b.Grow(size)
+	for r := range s.runes.Values() {
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (n *ropeNode[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// set returns a copy of the path to index with the item replaced
+func (n *ropeNode[T]) set(index int, value T) *ropeNode[T] {
+	leftSize := n.left.len()
+	switch {
+	case index < leftSize:
+		return newRopeNode(n.left.set(index, value), n.right, n.chunk, n.priority)
+	case index < leftSize+len(n.chunk):
+		chunk := make([]T, len(n.chunk))
+		copy(chunk, n.chunk)
+		chunk[index-leftSize] = value
+		return newRopeNode(n.left, n.right, chunk, n.priority)
+	default:
+		return newRopeNode(n.left, n.right.set(index-leftSize-len(n.chunk), value), n.chunk, n.priority)
+	}
+}
+
+func newRopeNode[T any](left *ropeNode[T], right *ropeNode[T], chunk []T, priority uint64) *ropeNode[T] {
+	return &ropeNode[T]{
+		left:     left,
+		right:    right,
+		chunk:    chunk,
+		size:     left.len() + len(chunk) + right.len(),
+		priority: priority,
+	}
+}
+
+// ropeBuild copies items into chunked nodes so the rope never aliases the caller's slice
+func ropeBuild[T any](items []T) *ropeNode[T] {
+	var root *ropeNode[T]
+	for start := 0; start < len(items); start += ropeChunkSize {
+		end := min(start+ropeChunkSize, len(items))
+		chunk := make([]T, end-start)
+		copy(chunk, items[start:end])
+		root = ropeMerge(root, newRopeNode(nil, nil, chunk, rand.Uint64()))
+	}
+	return root
+}
+
+// ropeJoin joins left, items and right in that order. The last chunk of left and the first chunk of right
+// are rebuilt together with items while they all fit in one chunk, so small edits do not leave tiny chunks behind.
+func ropeJoin[T any](left *ropeNode[T], items []T, right *ropeNode[T]) *ropeNode[T] {
+	middle := items
+	if last := left.last(); last != nil && len(last)+len(middle) <= ropeChunkSize {
+		left, _ = ropeSplit(left, left.len()-len(last))
+		middle = append(append(make([]T, 0, len(last)+len(middle)), last...), middle...)
+	}
+	if first := right.first(); first != nil && len(middle)+len(first) <= ropeChunkSize {
+		_, right = ropeSplit(right, len(first))
+		middle = append(append(make([]T, 0, len(middle)+len(first)), middle...), first...)
+	}
+	return ropeMerge(ropeMerge(left, ropeBuild(middle)), right)
+}
+
+// first returns the chunk holding the first items of the treap, or nil if it is empty
+func (n *ropeNode[T]) first() []T {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.chunk
+}
+
+// last returns the chunk holding the last items of the treap, or nil if it is empty
+func (n *ropeNode[T]) last() []T {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.chunk
+}
+
+// ropeMerge joins two treaps, every item of a coming before every item of b
+func ropeMerge[T any](a *ropeNode[T], b *ropeNode[T]) *ropeNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		return newRopeNode(a.left, ropeMerge(a.right, b), a.chunk, a.priority)
+	}
+	return newRopeNode(ropeMerge(a, b.left), b.right, b.chunk, b.priority)
+}
+
+// ropeSplit divides a treap into the first k items and the rest, splitting a chunk if k falls inside it
+func ropeSplit[T any](n *ropeNode[T], k int) (*ropeNode[T], *ropeNode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	leftSize := n.left.len()
+	if k <= leftSize {
+		left, right := ropeSplit(n.left, k)
+		return left, newRopeNode(right, n.right, n.chunk, n.priority)
+	}
+	k -= leftSize
+	if k >= len(n.chunk) {
+		left, right := ropeSplit(n.right, k-len(n.chunk))
+		return newRopeNode(n.left, left, n.chunk, n.priority), right
+	}
+	return newRopeNode(n.left, nil, n.chunk[:k], n.priority), newRopeNode(nil, n.right, n.chunk[k:], n.priority)
+}
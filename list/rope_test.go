@@ -0,0 +1,124 @@
+package kl
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// chunks counts the nodes of the rope
+func (n *ropeNode[T]) chunks() int {
+	if n == nil {
+		return 0
+	}
+	return 1 + n.left.chunks() + n.right.chunks()
+}
+
+func TestRopeMatchesList(t *testing.T) {
+	var rope Rope[int]
+	var model []int
+	next := 0
+	for range 2000 {
+		switch op := rand.Intn(5); {
+		case op < 2:
+			index := rand.Intn(len(model) + 1)
+			items := make([]int, rand.Intn(4)+1)
+			for i := range items {
+				items[i] = next
+				next++
+			}
+			if err := rope.Insert(index, items...); err != nil {
+				t.Fatal(err)
+			}
+			model = slices.Insert(model, index, items...)
+		case op == 2 && len(model) > 0:
+			start := rand.Intn(len(model))
+			end := start + rand.Intn(min(len(model)-start, 3)+1)
+			if err := rope.Delete(start, end); err != nil {
+				t.Fatal(err)
+			}
+			model = slices.Delete(model, start, end)
+		case op == 3:
+			rope.Add(next)
+			model = append(model, next)
+			next++
+		default:
+			snapshot := rope
+			tail := NewRope(next, next+1)
+			next += 2
+			rope.Concat(tail)
+			model = append(model, tail.ToList()...)
+			if snapshot.Len() != len(model)-2 {
+				t.Fatal("Concat changed an earlier snapshot")
+			}
+		}
+	}
+	if got := rope.ToList(); !slices.Equal(got, model) {
+		t.Fatalf("rope = %v, want %v", got, model)
+	}
+	for i, want := range model {
+		if got, ok := rope.Index(i); !ok || got != want {
+			t.Fatalf("Index(%d) = %d, %v, want %d", i, got, ok, want)
+		}
+	}
+	if _, ok := rope.Index(len(model)); ok {
+		t.Fatal("Index past the end succeeded")
+	}
+}
+
+func TestRopeCoalescesSmallEdits(t *testing.T) {
+	var rope Rope[int]
+	for i := range 1000 {
+		rope.Add(i)
+	}
+	if chunks, limit := rope.root.chunks(), 1000/ropeChunkSize+1; chunks > limit {
+		t.Errorf("1000 single Adds left %d chunks, want at most %d", chunks, limit)
+	}
+
+	rope.Clear()
+	for i := range 1000 {
+		if err := rope.Insert(rope.Len()/2, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Every chunk but the boundary ones after a split holds more than a couple of items
+	if chunks := rope.root.chunks(); chunks > 1000/(ropeChunkSize/4) {
+		t.Errorf("1000 single Inserts left %d chunks", chunks)
+	}
+}
+
+func TestStringRope(t *testing.T) {
+	s := NewStringRope("héllo wörld")
+	if err := s.Insert(5, ", ünïcode"); err != nil {
+		t.Fatal(err)
+	}
+	want := "héllo, ünïcode wörld"
+	if s.String() != want {
+		t.Fatalf("String = %q, want %q", s.String(), want)
+	}
+	if r, ok := s.Index(1); !ok || r != 'é' {
+		t.Fatalf("Index(1) = %q, %v", r, ok)
+	}
+
+	for _, substr := range []string{"", "h", "wörld", "ünï", "o", "d", "llo, ü", "missing", "héllo, ünïcode wörld!"} {
+		got, ok := s.IndexOf(substr)
+		byteIndex := strings.Index(want, substr)
+		wantIndex := -1
+		if byteIndex >= 0 {
+			wantIndex = len([]rune(want[:byteIndex]))
+		}
+		if got != wantIndex || ok != (byteIndex >= 0) {
+			t.Errorf("IndexOf(%q) = %d, %v, want %d", substr, got, ok, wantIndex)
+		}
+	}
+
+	// Overlapping prefixes need the fallback table
+	repeated, alternating := NewStringRope("aaabaaab"), NewStringRope("abababc")
+	if i, ok := repeated.IndexOf("aab"); !ok || i != 1 {
+		t.Errorf("IndexOf(aab) = %d, %v, want 1", i, ok)
+	}
+	if i, ok := alternating.IndexOf("ababc"); !ok || i != 2 {
+		t.Errorf("IndexOf(ababc) = %d, %v, want 2", i, ok)
+	}
+}
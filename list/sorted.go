@@ -0,0 +1,299 @@
+package kl
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// BinarySearch searches a sorted slice for target and returns the position where it is, or would be inserted, and whether it was found
+func BinarySearch[T cmp.Ordered, S ~[]T](list S, target T) (int, bool) {
+	return slices.BinarySearch(list, target)
+}
+
+// BinarySearchFunc searches a slice sorted by the comparator for target, see BinarySearch
+func BinarySearchFunc[T any, S ~[]T](list S, target T, comparator func(a, b T) int) (int, bool) {
+	return slices.BinarySearchFunc(list, target, comparator)
+}
+
+// BinarySearchBy searches a slice sorted by key for an element whose key is target, see BinarySearch
+func BinarySearchBy[T any, K cmp.Ordered, S ~[]T](list S, target K, key func(T) K) (int, bool) {
+	return slices.BinarySearchFunc(list, target, func(item T, target K) int {
+		return cmp.Compare(key(item), target)
+	})
+}
+
+// LowerBound returns the index of the first element of a sorted slice that is not less than target
+func LowerBound[T cmp.Ordered, S ~[]T](list S, target T) int {
+	index, _ := slices.BinarySearch(list, target)
+	return index
+}
+
+// UpperBound returns the index of the first element of a sorted slice that is greater than target
+func UpperBound[T cmp.Ordered, S ~[]T](list S, target T) int {
+	return sort.Search(len(list), func(i int) bool {
+		return list[i] > target
+	})
+}
+
+// LowerBoundFunc returns the index of the first element of a slice sorted by the comparator that does not sort before target
+func LowerBoundFunc[T any, S ~[]T](list S, target T, comparator func(a, b T) int) int {
+	index, _ := slices.BinarySearchFunc(list, target, comparator)
+	return index
+}
+
+// UpperBoundFunc returns the index of the first element of a slice sorted by the comparator that sorts after target
+func UpperBoundFunc[T any, S ~[]T](list S, target T, comparator func(a, b T) int) int {
+	return sort.Search(len(list), func(i int) bool {
+		return comparator(list[i], target) > 0
+	})
+}
+
+// InsertSorted inserts items into a sorted slice, keeping it sorted. Equal items are inserted after existing ones.
+func InsertSorted[T cmp.Ordered, S ~[]T](list *S, items ...T) {
+	InsertSortedFunc(list, cmp.Compare[T], items...)
+}
+
+// InsertSortedFunc inserts items into a slice sorted by the comparator, keeping it sorted.
+// Equal items are inserted after existing ones.
+func InsertSortedFunc[T any, S ~[]T](list *S, comparator func(a, b T) int, items ...T) {
+	for _, item := range items {
+		*list = slices.Insert(*list, UpperBoundFunc(*list, item, comparator), item)
+	}
+}
+
+// MergeSorted merges sorted slices into a single new sorted slice in O(n log k) time
+func MergeSorted[T cmp.Ordered, S ~[]T](lists ...S) S {
+	return MergeSortedFunc(cmp.Compare[T], lists...)
+}
+
+// MergeSortedFunc merges slices sorted by the comparator into a single new sorted slice in O(n log k) time.
+// Equal elements keep the order of the slices they came from.
+func MergeSortedFunc[T any, S ~[]T](comparator func(a, b T) int, lists ...S) S {
+	total := 0
+	h := mergeHeap[T]{comparator: comparator}
+	for i, list := range lists {
+		total += len(list)
+		if len(list) > 0 {
+			h.cursors = append(h.cursors, mergeCursor[T]{items: list, source: i})
+		}
+	}
+	heap.Init(&h)
+
+	result := make(S, 0, total)
+	for h.Len() > 0 {
+		cursor := &h.cursors[0]
+		result = append(result, cursor.items[0])
+		cursor.items = cursor.items[1:]
+		if len(cursor.items) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return result
+}
+
+type mergeCursor[T any] struct {
+	items  []T
+	source int
+}
+
+type mergeHeap[T any] struct {
+	cursors    []mergeCursor[T]
+	comparator func(a, b T) int
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.cursors) }
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.comparator(h.cursors[i].items[0], h.cursors[j].items[0]); c != 0 {
+		return c < 0
+	}
+	return h.cursors[i].source < h.cursors[j].source
+}
+func (h *mergeHeap[T]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+func (h *mergeHeap[T]) Push(x any)    { h.cursors = append(h.cursors, x.(mergeCursor[T])) }
+func (h *mergeHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
+
+// SortedList is a List that keeps its items sorted on every mutation,
+// so lookups use binary search instead of the linear IndexOf.
+// The zero value is an empty list in natural order when T is an integer, float or string type;
+// any other T needs NewSortedListFunc, and using its zero value panics.
+type SortedList[T any] struct {
+	items      List[T]
+	comparator Comparator[T]
+}
+
+// NewSortedList creates a new SortedList in natural order with the specified items
+func NewSortedList[T cmp.Ordered](items ...T) SortedList[T] {
+	return NewSortedListFunc(Ascending[T](), items...)
+}
+
+// NewSortedListFunc creates a new SortedList ordered by the comparator with the specified items
+func NewSortedListFunc[T any](comparator Comparator[T], items ...T) SortedList[T] {
+	sorted := List[T](slices.Clone(items))
+	slices.SortStableFunc(sorted, comparator)
+	return SortedList[T]{items: sorted, comparator: comparator}
+}
+
+// Add items, each at its sorted position
+//
+// Supports method chaining
+func (s *SortedList[T]) Add(items ...T) *SortedList[T] {
+	if len(items) > 1 && len(items) > s.items.Len()/8 {
+		// Bulk additions are cheaper to sort and merge than to insert one by one
+		added := List[T](slices.Clone(items))
+		slices.SortStableFunc(added, s.order())
+		s.items = MergeSortedFunc(s.order(), s.items, added)
+		return s
+	}
+	InsertSortedFunc(&s.items, s.order(), items...)
+	return s
+}
+
+// order returns the comparator, falling back to the natural order of T for the zero value
+func (s *SortedList[T]) order() Comparator[T] {
+	if s.comparator == nil {
+		compare, ok := kcodec.Comparator[T]()
+		if !ok {
+			panic(fmt.Sprintf("kl.SortedList: %v has no natural order, create the list with NewSortedListFunc", reflect.TypeFor[T]()))
+		}
+		s.comparator = compare
+	}
+	return s.comparator
+}
+
+// Get the item at index i, or false if the index is out of bounds
+func (s *SortedList[T]) Get(i ...int) (T, bool) {
+	return s.items.Get(i...)
+}
+
+// Remove the items at indices
+//
+// Errors: IndexError
+func (s *SortedList[T]) Remove(indices ...int) error {
+	return s.items.Remove(indices...)
+}
+
+// RemoveValues removes one occurrence of each item and returns the number of items removed
+func (s *SortedList[T]) RemoveValues(items ...T) int {
+	removed := 0
+	for _, item := range items {
+		if index, ok := s.IndexOf(item); ok {
+			s.items = slices.Delete(s.items, index, index+1)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Pop removes and returns the last (largest) item, or the item at index i if specified
+func (s *SortedList[T]) Pop(i ...int) (T, bool) {
+	return s.items.Pop(i...)
+}
+
+// IndexOf returns the index of the first item equal to item according to the comparator and true; otherwise (-1, false)
+func (s *SortedList[T]) IndexOf(item T) (int, bool) {
+	index, found := slices.BinarySearchFunc(s.items, item, s.order())
+	if !found {
+		return -1, false
+	}
+	return index, true
+}
+
+// Contains returns true if the list contains all of the provided items
+func (s *SortedList[T]) Contains(items ...T) bool {
+	for _, item := range items {
+		if _, ok := s.IndexOf(item); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// LowerBound returns the index of the first item that does not sort before item
+func (s *SortedList[T]) LowerBound(item T) int {
+	return LowerBoundFunc(s.items, item, s.order())
+}
+
+// UpperBound returns the index of the first item that sorts after item
+func (s *SortedList[T]) UpperBound(item T) int {
+	return UpperBoundFunc(s.items, item, s.order())
+}
+
+// Range returns a copy of the items from low (inclusive) to high (exclusive)
+func (s *SortedList[T]) Range(low T, high T) List[T] {
+	start := s.LowerBound(low)
+	end := max(start, s.LowerBound(high))
+	part := s.items[start:end]
+	return part.Copy()
+}
+
+// Min returns the first item, or false if the list is empty
+func (s *SortedList[T]) Min() (T, bool) {
+	return s.items.Get(0)
+}
+
+// Max returns the last item, or false if the list is empty
+func (s *SortedList[T]) Max() (T, bool) {
+	return s.items.Get()
+}
+
+// Len returns the len of the list
+func (s *SortedList[T]) Len() int {
+	return s.items.Len()
+}
+
+// IsEmpty returns true if the list is empty
+func (s *SortedList[T]) IsEmpty() bool {
+	return s.items.IsEmpty()
+}
+
+// Clear the list
+func (s *SortedList[T]) Clear() *SortedList[T] {
+	s.items.Clear()
+	return s
+}
+
+// Filter keeps items for which predicate returns true. Supports method chaining.
+func (s *SortedList[T]) Filter(predicate func(T) bool) *SortedList[T] {
+	s.items = slices.DeleteFunc(s.items, func(item T) bool {
+		return !predicate(item)
+	})
+	return s
+}
+
+// ForEach calls f for each item in order. Supports method chaining.
+func (s *SortedList[T]) ForEach(f func(T)) *SortedList[T] {
+	s.items.ForEach(f)
+	return s
+}
+
+// Copy returns a new independent copy of the sorted list
+func (s *SortedList[T]) Copy() SortedList[T] {
+	return SortedList[T]{items: s.items.Copy(), comparator: s.comparator}
+}
+
+// ToList returns a copy of the items as a List
+func (s *SortedList[T]) ToList() List[T] {
+	return s.items.Copy()
+}
+
+// ToSlice returns a copy of the items as a native slice
+func (s *SortedList[T]) ToSlice() []T {
+	return s.items.ToSlice()
+}
+
+// String returns the string representation of the list
+func (s *SortedList[T]) String() string {
+	return fmt.Sprintf("%v", []T(s.items))
+}
@@ -0,0 +1,154 @@
+package kl
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type score int
+
+func TestSortedListZeroValue(t *testing.T) {
+	var ints SortedList[int]
+	ints.Add(3, 1, 2).Add(0)
+	if got := ints.ToList(); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("zero value SortedList = %v, want [0 1 2 3]", got)
+	}
+	if i, ok := ints.IndexOf(2); !ok || i != 2 {
+		t.Fatalf("IndexOf(2) = %d, %v", i, ok)
+	}
+
+	var named SortedList[score]
+	named.Add(score(5), score(-1))
+	if got := named.ToList(); !slices.Equal(got, []score{-1, 5}) {
+		t.Fatalf("zero value SortedList of a named type = %v", got)
+	}
+
+	var words SortedList[string]
+	if !words.Add("b", "a").Contains("a") {
+		t.Fatal("zero value SortedList[string] lost an item")
+	}
+}
+
+func TestSortedListZeroValueWithoutOrder(t *testing.T) {
+	defer func() {
+		r := recover()
+		if message, ok := r.(string); !ok || !strings.Contains(message, "NewSortedListFunc") {
+			t.Fatalf("recovered %v, want a panic pointing to NewSortedListFunc", r)
+		}
+	}()
+	var points SortedList[struct{ X, Y int }]
+	points.Add(struct{ X, Y int }{1, 2})
+}
+
+func TestSortedListZeroValueKinds(t *testing.T) {
+	type celsius float32
+	type id uint8
+	var temperatures SortedList[celsius]
+	temperatures.Add(1.5, -3, 0.25)
+	if got := temperatures.ToList(); !slices.Equal(got, []celsius{-3, 0.25, 1.5}) {
+		t.Fatalf("zero value SortedList[celsius] = %v", got)
+	}
+	var ids SortedList[id]
+	ids.Add(200, 7, 255)
+	if got := ids.ToList(); !slices.Equal(got, []id{7, 200, 255}) {
+		t.Fatalf("zero value SortedList[id] = %v", got)
+	}
+	var small SortedList[int8]
+	small.Add(-128, 127, 0)
+	if got := small.ToList(); !slices.Equal(got, []int8{-128, 0, 127}) {
+		t.Fatalf("zero value SortedList[int8] = %v", got)
+	}
+}
+
+func TestBinarySearchAndBounds(t *testing.T) {
+	values := []int{1, 3, 3, 3, 7}
+	for _, c := range []struct {
+		target       int
+		index        int
+		found        bool
+		lower, upper int
+	}{
+		{0, 0, false, 0, 0},
+		{1, 0, true, 0, 1},
+		{3, 1, true, 1, 4},
+		{5, 4, false, 4, 4},
+		{7, 4, true, 4, 5},
+		{9, 5, false, 5, 5},
+	} {
+		if index, found := BinarySearch(values, c.target); index != c.index || found != c.found {
+			t.Errorf("BinarySearch(%d) = %d, %v, want %d, %v", c.target, index, found, c.index, c.found)
+		}
+		if got := LowerBound(values, c.target); got != c.lower {
+			t.Errorf("LowerBound(%d) = %d, want %d", c.target, got, c.lower)
+		}
+		if got := UpperBound(values, c.target); got != c.upper {
+			t.Errorf("UpperBound(%d) = %d, want %d", c.target, got, c.upper)
+		}
+		descending := Descending[int]()
+		reversed := []int{7, 3, 3, 3, 1}
+		if got, want := LowerBoundFunc(reversed, c.target, descending), len(values)-c.upper; got != want {
+			t.Errorf("LowerBoundFunc(%d) descending = %d, want %d", c.target, got, want)
+		}
+		if got, want := UpperBoundFunc(reversed, c.target, descending), len(values)-c.lower; got != want {
+			t.Errorf("UpperBoundFunc(%d) descending = %d, want %d", c.target, got, want)
+		}
+	}
+
+	people := []person{{"al", 20}, {"bo", 30}, {"cy", 40}}
+	if index, found := BinarySearchBy(people, 30, func(p person) int { return p.age }); index != 1 || !found {
+		t.Errorf("BinarySearchBy(30) = %d, %v, want 1, true", index, found)
+	}
+
+	var sorted SortedList[int]
+	sorted.Add(values...)
+	if sorted.LowerBound(3) != 1 || sorted.UpperBound(3) != 4 {
+		t.Errorf("SortedList bounds of 3 = %d, %d, want 1, 4", sorted.LowerBound(3), sorted.UpperBound(3))
+	}
+	if got := sorted.Range(2, 7); !slices.Equal(got, []int{3, 3, 3}) {
+		t.Errorf("Range(2, 7) = %v, want [3 3 3]", got)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	values := []int{1, 4}
+	InsertSorted(&values, 3, 0, 9, 4)
+	if want := []int{0, 1, 3, 4, 4, 9}; !slices.Equal(values, want) {
+		t.Fatalf("InsertSorted = %v, want %v", values, want)
+	}
+
+	// Equal items go after the existing ones
+	people := []person{{"al", 20}, {"bo", 30}}
+	InsertSortedFunc(&people, By(func(p person) int { return p.age }), person{"cy", 20}, person{"di", 30})
+	if got, want := names(people), []string{"al", "cy", "bo", "di"}; !slices.Equal(got, want) {
+		t.Fatalf("InsertSortedFunc = %v, want %v", got, want)
+	}
+
+	sorted := NewSortedListFunc(By(func(p person) int { return p.age }), person{"al", 20})
+	sorted.Add(person{"bo", 20})
+	sorted.Add(person{"cy", 20}, person{"di", 10})
+	if got, want := names(sorted.ToList()), []string{"di", "al", "bo", "cy"}; !slices.Equal(got, want) {
+		t.Fatalf("SortedList.Add = %v, want %v", got, want)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	if got, want := MergeSorted([]int{1, 4, 9}, nil, []int{2, 3, 10}, []int{0}), []int{0, 1, 2, 3, 4, 9, 10}; !slices.Equal(got, want) {
+		t.Fatalf("MergeSorted = %v, want %v", got, want)
+	}
+	if got := MergeSorted[int, []int](); len(got) != 0 {
+		t.Fatalf("MergeSorted() = %v, want empty", got)
+	}
+
+	// Equal elements keep the order of the sources they came from, whatever their position
+	byAge := By(func(p person) int { return p.age })
+	merged := MergeSortedFunc(byAge,
+		[]person{{"a1", 10}, {"a2", 20}, {"a3", 20}},
+		[]person{{"b1", 10}, {"b2", 20}},
+		[]person{{"c1", 5}, {"c2", 10}, {"c3", 20}},
+	)
+	want := []string{"c1", "a1", "b1", "c2", "a2", "a3", "b2", "c3"}
+	if got := names(merged); !slices.Equal(got, want) {
+		t.Fatalf("MergeSortedFunc = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+package kl
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// PGArray is a List that is stored as a PostgreSQL array literal such as {a,b,"c d"} instead of JSON
+type PGArray[T any] List[T]
+
+// PGArray converts the list so Value writes it as a PostgreSQL array literal
+func (l List[T]) PGArray() PGArray[T] {
+	return PGArray[T](l)
+}
+
+// Value implements driver.Valuer, storing the list as a JSON array. A nil list is stored as NULL.
+func (l List[T]) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal([]T(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner, reading either a JSON array or a PostgreSQL array literal.
+// NULL scans to a nil list, NULL elements of an array literal to the zero value (nil for pointer elements).
+//
+// Errors: InvalidEncodingError
+func (l *List[T]) Scan(src any) error {
+	var text []byte
+	switch src := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		text = src
+	case string:
+		text = []byte(src)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into a list", InvalidEncodingError, src)
+	}
+
+	text = bytes.TrimSpace(text)
+	switch {
+	case len(text) > 0 && text[0] == '[':
+		var items []T
+		if err := json.Unmarshal(text, &items); err != nil {
+			return fmt.Errorf("%w: %v", InvalidEncodingError, err)
+		}
+		*l = items
+		return nil
+	case len(text) > 0 && text[0] == '{':
+		items, err := parsePGArray[T](string(text))
+		if err != nil {
+			return fmt.Errorf("%w: %v", InvalidEncodingError, err)
+		}
+		*l = items
+		return nil
+	default:
+		return fmt.Errorf("%w: %q is neither a JSON array nor an array literal", InvalidEncodingError, text)
+	}
+}
+
+// Value implements driver.Valuer, storing the list as a PostgreSQL array literal. A nil list is stored as NULL.
+func (a PGArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		value := reflect.ValueOf(&a[i]).Elem()
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			b.WriteString("NULL")
+			continue
+		}
+		var text string
+		var err error
+		if kcodec.FormatsText(value.Type()) {
+			text, err = kcodec.FormatText(value, time.RFC3339Nano)
+		} else {
+			var data []byte
+			data, err = json.Marshal(a[i])
+			text = string(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		writePGElement(&b, text)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan implements sql.Scanner, see List.Scan
+//
+// Errors: InvalidEncodingError
+func (a *PGArray[T]) Scan(src any) error {
+	return (*List[T])(a).Scan(src)
+}
+
+// writePGElement writes text as an array element, quoting it when it would otherwise be misread
+func writePGElement(b *strings.Builder, text string) {
+	if text != "" && !strings.EqualFold(text, "NULL") && !strings.ContainsAny(text, "{}\",\\ \t\n\r\v\f") {
+		b.WriteString(text)
+		return
+	}
+	b.WriteByte('"')
+	for _, r := range text {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+}
+
+// parsePGArray parses a one-dimensional PostgreSQL array literal
+func parsePGArray[T any](text string) (List[T], error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("array literal %q must be enclosed in braces", text)
+	}
+	inner := text[1 : len(text)-1]
+	result := List[T]{}
+	if strings.TrimSpace(inner) == "" {
+		return result, nil
+	}
+
+	for i := 0; ; {
+		for i < len(inner) && isPGSpace(inner[i]) {
+			i++
+		}
+		var element strings.Builder
+		quoted := i < len(inner) && inner[i] == '"'
+		if quoted {
+			i++
+			for ; i < len(inner) && inner[i] != '"'; i++ {
+				if inner[i] == '\\' {
+					i++
+					if i == len(inner) {
+						break
+					}
+				}
+				element.WriteByte(inner[i])
+			}
+			if i == len(inner) {
+				return nil, fmt.Errorf("unterminated quoted element in %q", text)
+			}
+			i++
+		} else {
+			for ; i < len(inner) && inner[i] != ','; i++ {
+				switch inner[i] {
+				case '{', '}', '"':
+					return nil, fmt.Errorf("unexpected %q in %q, only one-dimensional arrays are supported", inner[i], text)
+				case '\\':
+					i++
+					if i == len(inner) {
+						return nil, fmt.Errorf("trailing backslash in %q", text)
+					}
+				}
+				element.WriteByte(inner[i])
+			}
+		}
+
+		var item T
+		raw := element.String()
+		if !quoted {
+			raw = strings.TrimRight(raw, " \t\n\r\v\f")
+		}
+		if !quoted && strings.EqualFold(raw, "NULL") {
+			result.Add(item)
+		} else if err := parsePGElement(&item, raw); err != nil {
+			return nil, fmt.Errorf("element %d: %w", result.Len(), err)
+		} else {
+			result.Add(item)
+		}
+
+		for i < len(inner) && isPGSpace(inner[i]) {
+			i++
+		}
+		if i == len(inner) {
+			return result, nil
+		}
+		if inner[i] != ',' {
+			return nil, fmt.Errorf("expected ',' at offset %d of %q", i+1, text)
+		}
+		i++
+	}
+}
+
+func parsePGElement[T any](item *T, text string) error {
+	value := reflect.ValueOf(item).Elem()
+	if !kcodec.ParsesText(value.Type()) {
+		return json.Unmarshal([]byte(text), item)
+	}
+	if text == "" && value.Kind() == reflect.Pointer {
+		value.Set(reflect.New(value.Type().Elem()))
+		return nil
+	}
+	return kcodec.ParseText(value, text, time.RFC3339Nano)
+}
+
+func isPGSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
@@ -0,0 +1,170 @@
+package kl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+// echoDriver is an in-memory driver whose every query returns one row holding its arguments,
+// so a value goes through Value on the way in and Scan on the way out like it would with a real database
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("transactions are not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *echoRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func init() {
+	sql.Register("kl-echo", echoDriver{})
+}
+
+func openEcho(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("kl-echo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// echo stores value and scans it back into dest
+func echo(t *testing.T, db *sql.DB, value any, dest any) {
+	t.Helper()
+	if err := db.QueryRow("echo", value).Scan(dest); err != nil {
+		t.Fatalf("echo %v: %v", value, err)
+	}
+}
+
+func TestSQLJSON(t *testing.T) {
+	db := openEcho(t)
+
+	var stored string
+	echo(t, db, NewList(1, 2, 3), &stored)
+	if stored != "[1,2,3]" {
+		t.Fatalf("Value = %q, want [1,2,3]", stored)
+	}
+
+	items := NewList("a", `quote " and \ backslash`, "NULL", "{}", "")
+	var got List[string]
+	echo(t, db, items, &got)
+	if !slices.Equal(got, items) {
+		t.Fatalf("round trip = %q, want %q", got, items)
+	}
+
+	var null any = "unset"
+	echo(t, db, List[int](nil), &null)
+	if null != nil {
+		t.Fatalf("nil list stored as %v, want NULL", null)
+	}
+	got = NewList("x")
+	echo(t, db, nil, &got)
+	if got != nil {
+		t.Fatalf("NULL scanned to %q, want a nil list", got)
+	}
+}
+
+func TestSQLPGArray(t *testing.T) {
+	db := openEcho(t)
+
+	items := NewList("plain", "with space", `quote"`, `back\slash`, "NULL", "null", "{}", "a,b", "")
+	var stored string
+	echo(t, db, items.PGArray(), &stored)
+	want := `{plain,"with space","quote\"","back\\slash","NULL","null","{}","a,b",""}`
+	if stored != want {
+		t.Fatalf("Value = %s, want %s", stored, want)
+	}
+
+	var got List[string]
+	echo(t, db, items.PGArray(), &got)
+	if !slices.Equal(got, items) {
+		t.Fatalf("round trip = %q, want %q", got, items)
+	}
+
+	var ints PGArray[int]
+	echo(t, db, []byte(" { 1 , -2,3 } "), &ints)
+	if !slices.Equal(ints, []int{1, -2, 3}) {
+		t.Fatalf("Scan = %v, want [1 -2 3]", ints)
+	}
+
+	var empty List[int]
+	echo(t, db, "{}", &empty)
+	if empty == nil || len(empty) != 0 {
+		t.Fatalf("{} scanned to %#v, want an empty list", empty)
+	}
+}
+
+func TestSQLPGArrayNullElements(t *testing.T) {
+	db := openEcho(t)
+
+	var pointers List[*string]
+	echo(t, db, `{NULL,"NULL",null,x,""}`, &pointers)
+	if len(pointers) != 5 {
+		t.Fatalf("Scan = %v, want 5 elements", pointers)
+	}
+	if pointers[0] != nil || pointers[2] != nil {
+		t.Fatalf("unquoted NULL elements scanned to %v and %v, want nil", pointers[0], pointers[2])
+	}
+	for i, want := range map[int]string{1: "NULL", 3: "x", 4: ""} {
+		if pointers[i] == nil || *pointers[i] != want {
+			t.Fatalf("element %d = %v, want %q", i, pointers[i], want)
+		}
+	}
+
+	var stored string
+	echo(t, db, pointers.PGArray(), &stored)
+	if want := `{NULL,"NULL",NULL,x,""}`; stored != want {
+		t.Fatalf("Value = %s, want %s", stored, want)
+	}
+
+	var values List[int]
+	echo(t, db, "{1,NULL,3}", &values)
+	if !slices.Equal(values, []int{1, 0, 3}) {
+		t.Fatalf("Scan = %v, want NULL as the zero value", values)
+	}
+}
+
+func TestSQLScanErrors(t *testing.T) {
+	db := openEcho(t)
+	for _, src := range []any{"1,2", "[1,", `{"a}`, "{{1},{2}}", `{a\}`, "{a b c", int64(4)} {
+		var l List[string]
+		err := db.QueryRow("echo", src).Scan(&l)
+		if !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("Scan(%v) = %v, want InvalidEncodingError", src, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package km
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// hashSeed is shared by the built-in hashers so equal values hash the same within a process
+var hashSeed = maphash.MakeSeed()
+
+// HashComparable hashes a comparable value consistently with ==
+func HashComparable[T comparable](item T) uint64 {
+	return maphash.Comparable(hashSeed, item)
+}
+
+// HashString hashes a string
+func HashString(s string) uint64 {
+	return maphash.String(hashSeed, s)
+}
+
+// HashNumber hashes a number consistently with ==, so 0.0 and -0.0 hash the same
+func HashNumber[N kl.RealNumber](n N) uint64 {
+	return maphash.Comparable(hashSeed, n)
+}
+
+// HashList returns a hasher for lists that combines the hashes of their elements in order
+func HashList[T any](hash func(T) uint64) func(kl.List[T]) uint64 {
+	return func(list kl.List[T]) uint64 {
+		var h maphash.Hash
+		h.SetSeed(hashSeed)
+		var buf [8]byte
+		for _, item := range list {
+			binary.LittleEndian.PutUint64(buf[:], hash(item))
+			_, _ = h.Write(buf[:])
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(list)))
+		_, _ = h.Write(buf[:])
+		return h.Sum64()
+	}
+}
+
+// Equal reports whether two comparable values are equal, for use as the equal function of a HashMap
+func Equal[T comparable](a T, b T) bool {
+	return a == b
+}
+
+// EqualList returns an equal function for lists that compares their elements in order
+func EqualList[T any](equal func(a, b T) bool) func(a, b kl.List[T]) bool {
+	return func(a, b kl.List[T]) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,190 @@
+package km
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// HashMap is a map whose keys need not be comparable, such as slices or structs containing slices.
+// Keys are located with a user supplied hash function and told apart with an equal function;
+// keys that are equal must have the same hash.
+//
+// The zero value is an empty map that can be read, but it has no hash function:
+// create maps with NewHashMap, as Set on the zero value panics.
+type HashMap[K any, V any] struct {
+	buckets map[uint64][]hashEntry[K, V]
+	length  int
+	hash    func(K) uint64
+	equal   func(a, b K) bool
+}
+
+type hashEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// NewHashMap creates a new empty HashMap. Panics if hash or equal is nil.
+func NewHashMap[K any, V any](hash func(K) uint64, equal func(a, b K) bool) *HashMap[K, V] {
+	if hash == nil || equal == nil {
+		panic("km.NewHashMap: hash and equal must not be nil")
+	}
+	return &HashMap[K, V]{buckets: map[uint64][]hashEntry[K, V]{}, hash: hash, equal: equal}
+}
+
+// Get returns the value stored for key, or false if the key is not present
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	if m.length > 0 {
+		bucket := m.buckets[m.hash(key)]
+		if i := m.find(bucket, key); i >= 0 {
+			return bucket[i].value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores value for key, replacing any value already stored for an equal key. Supports method chaining.
+func (m *HashMap[K, V]) Set(key K, value V) *HashMap[K, V] {
+	if m.hash == nil {
+		panic("km.HashMap.Set: the zero value has no hash function, use NewHashMap")
+	}
+	h := m.hash(key)
+	bucket := m.buckets[h]
+	if i := m.find(bucket, key); i >= 0 {
+		bucket[i].value = value
+		return m
+	}
+	m.buckets[h] = append(bucket, hashEntry[K, V]{key: key, value: value})
+	m.length++
+	return m
+}
+
+// Delete removes key from the map, returns false if the key was not present
+func (m *HashMap[K, V]) Delete(key K) bool {
+	if m.length == 0 {
+		return false
+	}
+	h := m.hash(key)
+	bucket := m.buckets[h]
+	i := m.find(bucket, key)
+	if i < 0 {
+		return false
+	}
+	if len(bucket) == 1 {
+		delete(m.buckets, h)
+	} else {
+		bucket[i] = bucket[len(bucket)-1]
+		bucket[len(bucket)-1] = hashEntry[K, V]{}
+		m.buckets[h] = bucket[:len(bucket)-1]
+	}
+	m.length--
+	return true
+}
+
+// Has returns true if the map contains all of the keys
+func (m *HashMap[K, V]) Has(keys ...K) bool {
+	for _, key := range keys {
+		if m.length == 0 || m.find(m.buckets[m.hash(key)], key) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of keys in the map
+func (m *HashMap[K, V]) Len() int {
+	return m.length
+}
+
+// IsEmpty returns true if the map is empty
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return m.length == 0
+}
+
+// Keys returns the keys in no particular order
+func (m *HashMap[K, V]) Keys() kl.List[K] {
+	result := make(kl.List[K], 0, m.length)
+	for key := range m.All() {
+		result = append(result, key)
+	}
+	return result
+}
+
+// Values returns the values in no particular order
+func (m *HashMap[K, V]) Values() kl.List[V] {
+	result := make(kl.List[V], 0, m.length)
+	for _, value := range m.All() {
+		result = append(result, value)
+	}
+	return result
+}
+
+// All returns an iterator over the keys and values in no particular order
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, bucket := range m.buckets {
+			for _, entry := range bucket {
+				if !yield(entry.key, entry.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ForEach calls f for each key and value. Supports method chaining.
+func (m *HashMap[K, V]) ForEach(f func(key K, value V)) *HashMap[K, V] {
+	for key, value := range m.All() {
+		f(key, value)
+	}
+	return m
+}
+
+// Clear all keys from the map
+func (m *HashMap[K, V]) Clear() *HashMap[K, V] {
+	clear(m.buckets)
+	m.length = 0
+	return m
+}
+
+// Copy returns a new shallow copy of the map using the same hash and equal functions
+func (m *HashMap[K, V]) Copy() *HashMap[K, V] {
+	result := &HashMap[K, V]{buckets: make(map[uint64][]hashEntry[K, V], len(m.buckets)), length: m.length, hash: m.hash, equal: m.equal}
+	for h, bucket := range m.buckets {
+		result.buckets[h] = append([]hashEntry[K, V](nil), bucket...)
+	}
+	return result
+}
+
+// Hasher returns the hash and equal functions of the map
+func (m *HashMap[K, V]) Hasher() (func(K) uint64, func(a, b K) bool) {
+	return m.hash, m.equal
+}
+
+// String returns the string representation of the map
+func (m *HashMap[K, V]) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	first := true
+	for key, value := range m.All() {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&b, "%v:%v", key, value)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func (m *HashMap[K, V]) find(bucket []hashEntry[K, V], key K) int {
+	for i := range bucket {
+		if m.equal(bucket[i].key, key) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+package km
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+func newListMap() *HashMap[kl.List[int], string] {
+	return NewHashMap[kl.List[int], string](HashList(HashNumber[int]), EqualList(Equal[int]))
+}
+
+func TestHashMapSetGetDelete(t *testing.T) {
+	m := newListMap()
+	m.Set(kl.List[int]{1, 2}, "a").Set(kl.List[int]{2, 1}, "b").Set(kl.List[int]{}, "empty")
+	m.Set(kl.List[int]{1, 2}, "A")
+	if m.Len() != 3 || m.IsEmpty() {
+		t.Fatalf("Len = %d, want 3", m.Len())
+	}
+	for _, c := range []struct {
+		key  kl.List[int]
+		want string
+		ok   bool
+	}{
+		{kl.List[int]{1, 2}, "A", true},
+		{kl.List[int]{2, 1}, "b", true},
+		{nil, "empty", true},
+		{kl.List[int]{1}, "", false},
+	} {
+		if got, ok := m.Get(c.key); got != c.want || ok != c.ok {
+			t.Errorf("Get(%v) = %q, %v, want %q, %v", c.key, got, ok, c.want, c.ok)
+		}
+	}
+	if !m.Has(kl.List[int]{1, 2}, kl.List[int]{2, 1}) || m.Has(kl.List[int]{1, 2}, kl.List[int]{3}) {
+		t.Error("Has gave the wrong answer")
+	}
+
+	if !m.Delete(kl.List[int]{2, 1}) || m.Delete(kl.List[int]{2, 1}) {
+		t.Error("Delete did not report the key exactly once")
+	}
+	if _, ok := m.Get(kl.List[int]{2, 1}); ok || m.Len() != 2 {
+		t.Errorf("after Delete: Len = %d, want 2 and the key gone", m.Len())
+	}
+	if m.Clear(); !m.IsEmpty() || m.Has(kl.List[int]{1, 2}) {
+		t.Error("Clear left keys behind")
+	}
+}
+
+func TestHashMapCollisions(t *testing.T) {
+	// Every key lands in the same bucket, so lookups rely on the equal function alone
+	m := NewHashMap[string, int](func(string) uint64 { return 7 }, Equal[string])
+	for i, key := range []string{"a", "b", "c", "d"} {
+		m.Set(key, i)
+	}
+	m.Delete("b")
+	m.Set("a", 10)
+	keys := m.Keys()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"a", "c", "d"}) {
+		t.Fatalf("Keys = %v, want [a c d]", keys)
+	}
+	for key, want := range map[string]int{"a": 10, "c": 2, "d": 3} {
+		if got, _ := m.Get(key); got != want {
+			t.Errorf("Get(%q) = %d, want %d", key, got, want)
+		}
+	}
+	values := m.Values()
+	slices.Sort(values)
+	if !slices.Equal(values, []int{2, 3, 10}) {
+		t.Errorf("Values = %v, want [2 3 10]", values)
+	}
+}
+
+func TestHashMapCopy(t *testing.T) {
+	fold := func(s string) uint64 { return HashString(strings.ToLower(s)) }
+	m := NewHashMap[string, int](fold, strings.EqualFold).Set("Go", 1)
+	c := m.Copy().Set("GO", 2).Set("rust", 3)
+	if got, _ := m.Get("go"); got != 1 || m.Len() != 1 {
+		t.Errorf("original changed by its copy: Get = %d, Len = %d", got, m.Len())
+	}
+	if got, _ := c.Get("go"); got != 2 || c.Len() != 2 {
+		t.Errorf("copy: Get = %d, Len = %d, want 2, 2", got, c.Len())
+	}
+	sum := 0
+	c.ForEach(func(_ string, value int) { sum += value })
+	if sum != 5 {
+		t.Errorf("ForEach visited values summing to %d, want 5", sum)
+	}
+	if got := c.Copy().Clear(); !got.IsEmpty() || c.IsEmpty() {
+		t.Error("clearing a copy changed the original")
+	}
+	if got := NewHashMap[string, int](HashString, Equal[string]).Set("k", 1).String(); got != "map[k:1]" {
+		t.Errorf("String = %q, want map[k:1]", got)
+	}
+}
+
+func TestHashMapZeroValue(t *testing.T) {
+	var m HashMap[kl.List[int], int]
+	if _, ok := m.Get(kl.List[int]{1}); ok || m.Has(kl.List[int]{1}) || m.Delete(kl.List[int]{1}) || !m.IsEmpty() {
+		t.Error("the zero value reported a key")
+	}
+	if len(m.Keys()) != 0 || m.Copy().Len() != 0 || m.Clear().String() != "map[]" {
+		t.Error("the zero value is not empty")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Set on the zero value did not panic")
+		}
+	}()
+	m.Set(kl.List[int]{1}, 1)
+}
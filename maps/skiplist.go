@@ -0,0 +1,343 @@
+// Package km contains map types that go beyond the builtin map
+package km
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const skipListMaxLevel = 32
+
+// SkipList is an ordered map that is safe for concurrent use.
+// Reads never lock, and writers only lock the nodes next to the key they modify,
+// so unrelated writes proceed in parallel (lazy skip list, Herlihy et al.).
+//
+// Iteration is weakly consistent: it sees every key present for the whole iteration
+// and may or may not see keys added or removed while it runs.
+type SkipList[K cmp.Ordered, V any] struct {
+	head   *skipNode[K, V]
+	length atomic.Int64
+}
+
+type skipNode[K cmp.Ordered, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[skipNode[K, V]]
+	mu          sync.Mutex
+	marked      atomic.Bool // logically deleted
+	fullyLinked atomic.Bool // linked at every level, so logically present
+}
+
+// NewSkipList creates a new empty SkipList
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	head := &skipNode[K, V]{next: make([]atomic.Pointer[skipNode[K, V]], skipListMaxLevel)}
+	head.fullyLinked.Store(true)
+	return &SkipList[K, V]{head: head}
+}
+
+// Len returns the number of keys in the skip list
+func (s *SkipList[K, V]) Len() int {
+	return int(s.length.Load())
+}
+
+// IsEmpty returns true if the skip list is empty
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Get returns the value stored for key, or false if the key is not present
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*skipNode[K, V]
+	found := s.find(key, &preds, &succs)
+	if found == -1 || !succs[found].present() {
+		var zero V
+		return zero, false
+	}
+	return *succs[found].value.Load(), true
+}
+
+// Contains returns true if the skip list contains all of the keys
+func (s *SkipList[K, V]) Contains(keys ...K) bool {
+	for _, key := range keys {
+		if _, ok := s.Get(key); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Put stores value for key, returns true if the key was added and false if an existing value was replaced
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	topLevel := randomSkipLevel()
+	var preds, succs [skipListMaxLevel]*skipNode[K, V]
+	for {
+		found := s.find(key, &preds, &succs)
+		if found != -1 {
+			node := succs[found]
+			if !node.marked.Load() {
+				// Another writer is still linking this key, wait until it is visible
+				for !node.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				node.value.Store(&value)
+				return false
+			}
+			// The key is being deleted, retry once it is unlinked
+			continue
+		}
+
+		highestLocked := -1
+		valid := true
+		var prevPred *skipNode[K, V]
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				highestLocked = level
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[level].Load() == succ
+		}
+		if !valid {
+			unlockSkipPreds(&preds, highestLocked)
+			continue
+		}
+
+		node := &skipNode[K, V]{key: key, next: make([]atomic.Pointer[skipNode[K, V]], topLevel)}
+		node.value.Store(&value)
+		for level := 0; level < topLevel; level++ {
+			node.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(node)
+		}
+		node.fullyLinked.Store(true)
+		unlockSkipPreds(&preds, highestLocked)
+		s.length.Add(1)
+		return true
+	}
+}
+
+// Delete removes key from the skip list, returns false if the key was not present
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var preds, succs [skipListMaxLevel]*skipNode[K, V]
+	var victim *skipNode[K, V]
+	isMarked := false
+	for {
+		found := s.find(key, &preds, &succs)
+		if !isMarked {
+			if found == -1 {
+				return false
+			}
+			victim = succs[found]
+			// Only delete nodes found at their top level, otherwise they are still being linked
+			if !victim.present() || len(victim.next)-1 != found {
+				return false
+			}
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked := -1
+		valid := true
+		var prevPred *skipNode[K, V]
+		for level := 0; valid && level < len(victim.next); level++ {
+			pred := preds[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				highestLocked = level
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == victim
+		}
+		if !valid {
+			unlockSkipPreds(&preds, highestLocked)
+			continue
+		}
+
+		for level := len(victim.next) - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mu.Unlock()
+		unlockSkipPreds(&preds, highestLocked)
+		s.length.Add(-1)
+		return true
+	}
+}
+
+// First returns the smallest key and its value, or false if the skip list is empty
+func (s *SkipList[K, V]) First() (K, V, bool) {
+	return skipEntry(s.firstFrom(s.head.next[0].Load()))
+}
+
+// Last returns the largest key and its value, or false if the skip list is empty
+func (s *SkipList[K, V]) Last() (K, V, bool) {
+	var key K
+	return skipEntry(s.lastBefore(key, false, true))
+}
+
+// Floor returns the largest key less than or equal to key and its value, or false if there is none
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	return skipEntry(s.lastBefore(key, true, false))
+}
+
+// Lower returns the largest key strictly less than key and its value, or false if there is none
+func (s *SkipList[K, V]) Lower(key K) (K, V, bool) {
+	return skipEntry(s.lastBefore(key, false, false))
+}
+
+// Ceiling returns the smallest key greater than or equal to key and its value, or false if there is none
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return skipEntry(s.firstFrom(s.seek(key, false)))
+}
+
+// Higher returns the smallest key strictly greater than key and its value, or false if there is none
+func (s *SkipList[K, V]) Higher(key K) (K, V, bool) {
+	return skipEntry(s.firstFrom(s.seek(key, true)))
+}
+
+// All returns an iterator over all keys and values in ascending key order
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.firstFrom(s.head.next[0].Load()); node != nil; node = s.firstFrom(node.next[0].Load()) {
+			if !yield(node.key, *node.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys from start (inclusive) to end (exclusive) and their values in ascending key order
+func (s *SkipList[K, V]) Range(start K, end K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.firstFrom(s.seek(start, false)); node != nil && node.key < end; node = s.firstFrom(node.next[0].Load()) {
+			if !yield(node.key, *node.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns all keys in ascending order
+func (s *SkipList[K, V]) Keys() kl.List[K] {
+	result := kl.NewListCap[K](s.Len())
+	for key := range s.All() {
+		result.Add(key)
+	}
+	return result
+}
+
+// Values returns all values in ascending key order
+func (s *SkipList[K, V]) Values() kl.List[V] {
+	result := kl.NewListCap[V](s.Len())
+	for _, value := range s.All() {
+		result.Add(value)
+	}
+	return result
+}
+
+// find fills preds and succs with the nodes around key at every level
+// and returns the highest level key was found at, or -1
+func (s *SkipList[K, V]) find(key K, preds, succs *[skipListMaxLevel]*skipNode[K, V]) int {
+	found := -1
+	pred := s.head
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && curr.key < key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found == -1 && curr != nil && curr.key == key {
+			found = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return found
+}
+
+// seek returns the first node at level 0 with a key greater than or equal to key (or strictly greater if exclusive)
+func (s *SkipList[K, V]) seek(key K, exclusive bool) *skipNode[K, V] {
+	pred := s.head
+	var curr *skipNode[K, V]
+	for level := skipListMaxLevel - 1; level >= 0; level-- {
+		curr = pred.next[level].Load()
+		for curr != nil && (curr.key < key || exclusive && curr.key == key) {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+	}
+	// Reloading pred.next[0] here could return a smaller key inserted after the walk
+	return curr
+}
+
+// lastBefore returns the last present node with a key less than key (or equal if inclusive),
+// or the last present node of the list if unbounded
+func (s *SkipList[K, V]) lastBefore(key K, inclusive bool, unbounded bool) *skipNode[K, V] {
+	for {
+		pred := s.head
+		for level := skipListMaxLevel - 1; level >= 0; level-- {
+			curr := pred.next[level].Load()
+			for curr != nil && (unbounded || curr.key < key || inclusive && curr.key == key) {
+				pred = curr
+				curr = pred.next[level].Load()
+			}
+		}
+		if pred == s.head {
+			return nil
+		}
+		if pred.present() {
+			return pred
+		}
+		// pred is being added or removed, so it is absent: look for the key before it
+		key, inclusive, unbounded = pred.key, false, false
+	}
+}
+
+// firstFrom returns node or the first present node after it at level 0
+func (s *SkipList[K, V]) firstFrom(node *skipNode[K, V]) *skipNode[K, V] {
+	for node != nil && !node.present() {
+		node = node.next[0].Load()
+	}
+	return node
+}
+
+func (n *skipNode[K, V]) present() bool {
+	return n.fullyLinked.Load() && !n.marked.Load()
+}
+
+func skipEntry[K cmp.Ordered, V any](node *skipNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.key, *node.value.Load(), true
+}
+
+func unlockSkipPreds[K cmp.Ordered, V any](preds *[skipListMaxLevel]*skipNode[K, V], highestLocked int) {
+	var prevPred *skipNode[K, V]
+	for level := 0; level <= highestLocked; level++ {
+		if preds[level] != prevPred {
+			preds[level].mu.Unlock()
+			prevPred = preds[level]
+		}
+	}
+}
+
+// randomSkipLevel returns a level between 1 and skipListMaxLevel with probability halving per level
+func randomSkipLevel() int {
+	return min(bits.TrailingZeros64(rand.Uint64())+1, skipListMaxLevel)
+}
@@ -0,0 +1,251 @@
+package km
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSkipListPutGetDelete(t *testing.T) {
+	s := NewSkipList[int, string]()
+	if _, ok := s.Get(1); ok || !s.IsEmpty() {
+		t.Fatal("empty skip list reported a key")
+	}
+	if !s.Put(2, "b") || !s.Put(1, "a") || !s.Put(3, "c") {
+		t.Fatal("Put of a new key returned false")
+	}
+	if s.Put(2, "B") {
+		t.Fatal("Put of an existing key returned true")
+	}
+	if v, ok := s.Get(2); !ok || v != "B" {
+		t.Fatalf("Get(2) = %q, %v, want \"B\", true", v, ok)
+	}
+	if s.Len() != 3 || !s.Contains(1, 2, 3) || s.Contains(1, 4) {
+		t.Fatalf("Len, Contains wrong after Put: %v", s.Keys())
+	}
+	if !s.Delete(2) || s.Delete(2) || s.Delete(9) {
+		t.Fatal("Delete reported the wrong presence")
+	}
+	if _, ok := s.Get(2); ok || s.Len() != 2 {
+		t.Fatal("deleted key is still present")
+	}
+	if !s.Put(2, "again") {
+		t.Fatal("Put after Delete returned false")
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(s.Keys(), want) {
+		t.Fatalf("Keys = %v, want %v", s.Keys(), want)
+	}
+	if want := []string{"a", "again", "c"}; !slices.Equal(s.Values(), want) {
+		t.Fatalf("Values = %v, want %v", s.Values(), want)
+	}
+}
+
+func TestSkipListNavigation(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for _, k := range []int{10, 20, 30, 40} {
+		s.Put(k, k*10)
+	}
+	s.Put(25, 250)
+	s.Delete(25)
+
+	type result struct {
+		key int
+		ok  bool
+	}
+	entry := func(k, v int, ok bool) result {
+		if ok && v != k*10 {
+			t.Fatalf("key %d has value %d", k, v)
+		}
+		return result{k, ok}
+	}
+	for _, c := range []struct {
+		name      string
+		got, want result
+	}{
+		{"Floor(20)", entry(s.Floor(20)), result{20, true}},
+		{"Floor(25)", entry(s.Floor(25)), result{20, true}},
+		{"Floor(5)", entry(s.Floor(5)), result{0, false}},
+		{"Lower(20)", entry(s.Lower(20)), result{10, true}},
+		{"Lower(10)", entry(s.Lower(10)), result{0, false}},
+		{"Lower(99)", entry(s.Lower(99)), result{40, true}},
+		{"Ceiling(20)", entry(s.Ceiling(20)), result{20, true}},
+		{"Ceiling(25)", entry(s.Ceiling(25)), result{30, true}},
+		{"Ceiling(41)", entry(s.Ceiling(41)), result{0, false}},
+		{"Higher(20)", entry(s.Higher(20)), result{30, true}},
+		{"Higher(40)", entry(s.Higher(40)), result{0, false}},
+		{"Higher(-1)", entry(s.Higher(-1)), result{10, true}},
+		{"First", entry(s.First()), result{10, true}},
+		{"Last", entry(s.Last()), result{40, true}},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	empty := NewSkipList[int, int]()
+	if _, _, ok := empty.First(); ok {
+		t.Error("First of empty skip list reported a key")
+	}
+	if _, _, ok := empty.Floor(1); ok {
+		t.Error("Floor of empty skip list reported a key")
+	}
+}
+
+func TestSkipListRange(t *testing.T) {
+	s := NewSkipList[int, int]()
+	for i := range 20 {
+		s.Put(i*2, i)
+	}
+	collect := func(start, end int) []int {
+		var keys []int
+		for k := range s.Range(start, end) {
+			keys = append(keys, k)
+		}
+		return keys
+	}
+	for _, c := range []struct {
+		start, end int
+		want       []int
+	}{
+		{4, 10, []int{4, 6, 8}},
+		{3, 9, []int{4, 6, 8}},
+		{-5, 3, []int{0, 2}},
+		{36, 100, []int{36, 38}},
+		{10, 10, nil},
+		{10, 4, nil},
+	} {
+		if got := collect(c.start, c.end); !slices.Equal(got, c.want) {
+			t.Errorf("Range(%d, %d) = %v, want %v", c.start, c.end, got, c.want)
+		}
+	}
+
+	// Stopping early must not visit further keys
+	visited := 0
+	for range s.All() {
+		visited++
+		if visited == 3 {
+			break
+		}
+	}
+	if visited != 3 {
+		t.Fatalf("All visited %d keys after break, want 3", visited)
+	}
+}
+
+func TestSkipListMatchesMap(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	s := NewSkipList[int, int]()
+	reference := map[int]int{}
+	for range 5000 {
+		k := r.IntN(300)
+		if r.IntN(3) == 0 {
+			_, had := reference[k]
+			delete(reference, k)
+			if s.Delete(k) != had {
+				t.Fatalf("Delete(%d) disagrees with the reference", k)
+			}
+			continue
+		}
+		_, had := reference[k]
+		reference[k] = r.Int()
+		if s.Put(k, reference[k]) == had {
+			t.Fatalf("Put(%d) disagrees with the reference", k)
+		}
+	}
+	keys := slices.Sorted(func(yield func(int) bool) {
+		for k := range reference {
+			if !yield(k) {
+				return
+			}
+		}
+	})
+	if !slices.Equal(s.Keys(), keys) || s.Len() != len(keys) {
+		t.Fatalf("Keys = %v, want %v", s.Keys(), keys)
+	}
+	for k, v := range s.All() {
+		if reference[k] != v {
+			t.Fatalf("key %d has value %d, want %d", k, v, reference[k])
+		}
+	}
+}
+
+func TestSkipListConcurrent(t *testing.T) {
+	const writers, perWriter = 8, 500
+	s := NewSkipList[int, int]()
+	stop := make(chan struct{})
+
+	// Readers check the ordering invariants while the writers run
+	var readers sync.WaitGroup
+	for range 4 {
+		readers.Go(func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				previous, started := 0, false
+				for k, v := range s.All() {
+					if started && k <= previous {
+						t.Errorf("iteration saw %d after %d", k, previous)
+						return
+					}
+					if v != k {
+						t.Errorf("key %d has value %d", k, v)
+						return
+					}
+					previous, started = k, true
+				}
+				if k, _, ok := s.Ceiling(writers * perWriter / 2); ok && k < writers*perWriter/2 {
+					t.Errorf("Ceiling returned smaller key %d", k)
+					return
+				}
+			}
+		})
+	}
+
+	// Each writer owns the keys congruent to its index, inserts them all, then deletes the odd ones
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Go(func() {
+			for i := range perWriter {
+				k := i*writers + w
+				if !s.Put(k, k) {
+					t.Errorf("Put(%d) found the key already present", k)
+				}
+			}
+			for i := range perWriter {
+				if k := i*writers + w; k%2 == 1 && !s.Delete(k) {
+					t.Errorf("Delete(%d) did not find the key", k)
+				}
+			}
+		})
+	}
+	// Contended writers race on the same keys
+	for range writers {
+		wg.Go(func() {
+			for k := range 200 {
+				s.Put(-1-k, -1-k)
+				s.Delete(-1 - k)
+			}
+		})
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	// Every contended key was deleted after its last Put
+	if k, _, ok := s.First(); ok && k < 0 {
+		t.Fatalf("contended key %d survived its Delete", k)
+	}
+	want := writers * perWriter / 2
+	if s.Len() != want || len(s.Keys()) != want {
+		t.Fatalf("Len = %d with %d keys, want %d", s.Len(), len(s.Keys()), want)
+	}
+	for i, k := range s.Keys() {
+		if k != i*2 {
+			t.Fatalf("key %d is %d, want %d", i, k, i*2)
+		}
+	}
+}
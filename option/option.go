@@ -0,0 +1,120 @@
+// Package ko contains the Option and Result types for values that may be missing or may have failed to compute
+package ko
+
+import "fmt"
+
+// Option holds either a value (Some) or nothing (None). The zero value is None.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some creates an Option holding value
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: value, ok: true}
+}
+
+// None creates an empty Option
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionOf creates an Option from the (value, ok) pair returned by map lookups and methods such as kl.List.Get
+func OptionOf[T any](value T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(value)
+}
+
+// IsSome returns true if the option holds a value
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone returns true if the option is empty
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// Get returns the value and true, or the zero value and false if the option is empty
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// Unwrap returns the value, panicking if the option is empty
+func (o Option[T]) Unwrap() T {
+	return o.Expect("ko.Option.Unwrap: option is None")
+}
+
+// Expect returns the value, panicking with message if the option is empty
+func (o Option[T]) Expect(message string) T {
+	if !o.ok {
+		panic(message)
+	}
+	return o.value
+}
+
+// OrElse returns the value, or fallback if the option is empty
+func (o Option[T]) OrElse(fallback T) T {
+	if !o.ok {
+		return fallback
+	}
+	return o.value
+}
+
+// OrElseGet returns the value, or the result of fallback if the option is empty
+func (o Option[T]) OrElseGet(fallback func() T) T {
+	if !o.ok {
+		return fallback()
+	}
+	return o.value
+}
+
+// Or returns the option if it holds a value, otherwise other
+func (o Option[T]) Or(other Option[T]) Option[T] {
+	if !o.ok {
+		return other
+	}
+	return o
+}
+
+// Filter returns the option if it holds a value for which predicate returns true, otherwise None
+func (o Option[T]) Filter(predicate func(T) bool) Option[T] {
+	if !o.ok || !predicate(o.value) {
+		return None[T]()
+	}
+	return o
+}
+
+// OkOr converts the option to a Result, using err if the option is empty
+func (o Option[T]) OkOr(err error) Result[T] {
+	if !o.ok {
+		return Err[T](err)
+	}
+	return Ok(o.value)
+}
+
+// String returns Some(value) or None
+func (o Option[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// Map applies f to the value of the option, if any
+func Map[T any, U any](o Option[T], f func(T) U) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+// FlatMap applies f to the value of the option, if any, returning the option f returns
+func FlatMap[T any, U any](o Option[T], f func(T) Option[U]) Option[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return f(o.value)
+}
@@ -0,0 +1,99 @@
+package ko
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// panics reports whether f panics, and with what
+func panics(f func()) (message any) {
+	defer func() { message = recover() }()
+	f()
+	return nil
+}
+
+func TestOption(t *testing.T) {
+	some, none := Some(4), None[int]()
+	var zero Option[int]
+	if !some.IsSome() || some.IsNone() || none.IsSome() || !none.IsNone() || zero.IsSome() {
+		t.Fatal("IsSome or IsNone gave the wrong answer")
+	}
+	if v, ok := some.Get(); v != 4 || !ok {
+		t.Errorf("Some(4).Get = %d, %v", v, ok)
+	}
+	if v, ok := none.Get(); v != 0 || ok {
+		t.Errorf("None.Get = %d, %v", v, ok)
+	}
+	if OptionOf(0, true) != Some(0) || OptionOf(5, false) != none {
+		t.Error("OptionOf does not follow ok")
+	}
+
+	if some.Unwrap() != 4 || some.Expect("missing") != 4 {
+		t.Error("Unwrap or Expect of Some did not return the value")
+	}
+	if got := panics(func() { none.Unwrap() }); got != "ko.Option.Unwrap: option is None" {
+		t.Errorf("None.Unwrap panicked with %v", got)
+	}
+	if got := panics(func() { none.Expect("need a port") }); got != "need a port" {
+		t.Errorf("None.Expect panicked with %v", got)
+	}
+
+	calls := 0
+	fallback := func() int { calls++; return 9 }
+	if some.OrElse(9) != 4 || none.OrElse(9) != 9 || some.OrElseGet(fallback) != 4 || none.OrElseGet(fallback) != 9 {
+		t.Error("OrElse or OrElseGet returned the wrong value")
+	}
+	if calls != 1 {
+		t.Errorf("OrElseGet called its fallback %d times, want once", calls)
+	}
+	if some.Or(Some(1)) != some || none.Or(Some(1)) != Some(1) || none.Or(none) != none {
+		t.Error("Or returned the wrong option")
+	}
+
+	even := func(n int) bool { return n%2 == 0 }
+	if some.Filter(even) != some || Some(3).Filter(even) != none || none.Filter(even) != none {
+		t.Error("Filter returned the wrong option")
+	}
+	if some.String() != "Some(4)" || none.String() != "None" {
+		t.Errorf("String = %s, %s", some, none)
+	}
+}
+
+func TestOptionCombinators(t *testing.T) {
+	if got := Map(Some(4), strconv.Itoa); got != Some("4") {
+		t.Errorf("Map(Some) = %v", got)
+	}
+	if got := Map(None[int](), strconv.Itoa); got != None[string]() {
+		t.Errorf("Map(None) = %v", got)
+	}
+
+	half := func(n int) Option[int] {
+		if n%2 != 0 {
+			return None[int]()
+		}
+		return Some(n / 2)
+	}
+	for _, c := range []struct {
+		in, want Option[int]
+	}{
+		{Some(8), Some(4)},
+		{Some(3), None[int]()},
+		{None[int](), None[int]()},
+	} {
+		if got := FlatMap(c.in, half); got != c.want {
+			t.Errorf("FlatMap(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+	if got := FlatMap(FlatMap(Some(12), half), half); got != Some(3) {
+		t.Errorf("chained FlatMap = %v, want Some(3)", got)
+	}
+
+	missing := errors.New("missing")
+	if r := Some(1).OkOr(missing); !r.IsOk() || r.Unwrap() != 1 {
+		t.Errorf("Some.OkOr = %v", r)
+	}
+	if r := None[int]().OkOr(missing); !errors.Is(r.Err(), missing) {
+		t.Errorf("None.OkOr = %v", r)
+	}
+}
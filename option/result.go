@@ -0,0 +1,114 @@
+package ko
+
+import "fmt"
+
+// Result holds either a value (Ok) or an error (Err). The zero value is Ok with the zero value.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok creates a successful Result holding value
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err creates a failed Result holding err. Panics if err is nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("ko.Err: err must not be nil")
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf creates a Result from the (value, error) pair returned by most functions
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// IsOk returns true if the result holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr returns true if the result holds an error
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Get returns the value and error in the usual Go form
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error, or nil if the result is Ok
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap returns the value, panicking with the error if the result is Err
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("ko.Result.Unwrap: %v", r.err))
+	}
+	return r.value
+}
+
+// Expect returns the value, panicking with message and the error if the result is Err
+func (r Result[T]) Expect(message string) T {
+	if r.err != nil {
+		panic(fmt.Sprintf("%s: %v", message, r.err))
+	}
+	return r.value
+}
+
+// OrElse returns the value, or fallback if the result is Err
+func (r Result[T]) OrElse(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// OrElseGet returns the value, or the result of fallback applied to the error if the result is Err
+func (r Result[T]) OrElseGet(fallback func(error) T) T {
+	if r.err != nil {
+		return fallback(r.err)
+	}
+	return r.value
+}
+
+// Option converts the result to an Option, dropping the error
+func (r Result[T]) Option() Option[T] {
+	if r.err != nil {
+		return None[T]()
+	}
+	return Some(r.value)
+}
+
+// String returns Ok(value) or Err(error)
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies f to the value of the result, if it is Ok
+func MapResult[T any, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// FlatMapResult applies f to the value of the result, if it is Ok, returning the result f returns
+func FlatMapResult[T any, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return f(r.value)
+}
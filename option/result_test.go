@@ -0,0 +1,80 @@
+package ko
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	failed := errors.New("failed")
+	ok, bad := Ok(4), Err[int](failed)
+	var zero Result[int]
+	if !ok.IsOk() || ok.IsErr() || bad.IsOk() || !bad.IsErr() || !zero.IsOk() {
+		t.Fatal("IsOk or IsErr gave the wrong answer")
+	}
+	if v, err := ok.Get(); v != 4 || err != nil {
+		t.Errorf("Ok(4).Get = %d, %v", v, err)
+	}
+	if v, err := bad.Get(); v != 0 || err != failed || bad.Err() != failed || ok.Err() != nil {
+		t.Errorf("Err.Get = %d, %v", v, err)
+	}
+	if got := panics(func() { Err[int](nil) }); got == nil {
+		t.Error("Err(nil) did not panic")
+	}
+
+	if r := ResultOf(strconv.Atoi("12")); r != Ok(12) {
+		t.Errorf("ResultOf(12) = %v", r)
+	}
+	if r := ResultOf(strconv.Atoi("x")); !r.IsErr() || !errors.Is(r.Err(), strconv.ErrSyntax) {
+		t.Errorf("ResultOf(x) = %v, want a syntax error", r)
+	}
+
+	if ok.Unwrap() != 4 || ok.Expect("parse") != 4 {
+		t.Error("Unwrap or Expect of Ok did not return the value")
+	}
+	if got := panics(func() { bad.Unwrap() }); got != "ko.Result.Unwrap: failed" {
+		t.Errorf("Err.Unwrap panicked with %v", got)
+	}
+	if got := panics(func() { bad.Expect("parse") }); got != "parse: failed" {
+		t.Errorf("Err.Expect panicked with %v", got)
+	}
+
+	var seen error
+	fallback := func(err error) int { seen = err; return 9 }
+	if ok.OrElse(9) != 4 || bad.OrElse(9) != 9 || ok.OrElseGet(fallback) != 4 || seen != nil {
+		t.Error("OrElse or OrElseGet of Ok returned the wrong value")
+	}
+	if bad.OrElseGet(fallback) != 9 || seen != failed {
+		t.Errorf("Err.OrElseGet passed %v to its fallback", seen)
+	}
+	if ok.Option() != Some(4) || bad.Option() != None[int]() {
+		t.Error("Option dropped the wrong side")
+	}
+	if ok.String() != "Ok(4)" || bad.String() != "Err(failed)" {
+		t.Errorf("String = %s, %s", ok, bad)
+	}
+}
+
+func TestResultCombinators(t *testing.T) {
+	failed := errors.New("failed")
+	if got := MapResult(Ok(4), strconv.Itoa); got != Ok("4") {
+		t.Errorf("MapResult(Ok) = %v", got)
+	}
+	if got := MapResult(Err[int](failed), strconv.Itoa); got.Err() != failed {
+		t.Errorf("MapResult(Err) = %v, want the error kept", got)
+	}
+
+	parse := func(s string) Result[int] { return ResultOf(strconv.Atoi(s)) }
+	if got := FlatMapResult(Ok("12"), parse); got != Ok(12) {
+		t.Errorf("FlatMapResult(Ok(12)) = %v", got)
+	}
+	if got := FlatMapResult(Ok("x"), parse); !errors.Is(got.Err(), strconv.ErrSyntax) {
+		t.Errorf("FlatMapResult(Ok(x)) = %v, want the error from f", got)
+	}
+	called := false
+	got := FlatMapResult(Err[string](failed), func(s string) Result[int] { called = true; return parse(s) })
+	if got.Err() != failed || called {
+		t.Errorf("FlatMapResult(Err) = %v, called f: %v", got, called)
+	}
+}
@@ -0,0 +1,61 @@
+package kp
+
+import (
+	"errors"
+	"fmt"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p Pair[A, B]) MarshalBinary() ([]byte, error) {
+	data, err := kcodec.AppendValues([]byte{'p', kcodec.Version}, []A{p.A})
+	if err != nil {
+		return nil, err
+	}
+	return kcodec.AppendValues(data, []B{p.B})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (p *Pair[A, B]) UnmarshalBinary(data []byte) error {
+	result, err := unmarshalPair[A, B](data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", InvalidEncodingError, err)
+	}
+	*p = result
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format
+func (p Pair[A, B]) GobEncode() ([]byte, error) {
+	return p.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+//
+// Errors: InvalidEncodingError
+func (p *Pair[A, B]) GobDecode(data []byte) error {
+	return p.UnmarshalBinary(data)
+}
+
+func unmarshalPair[A any, B any](data []byte) (Pair[A, B], error) {
+	var result Pair[A, B]
+	data, err := kcodec.ReadHeader('p', data)
+	if err != nil {
+		return result, err
+	}
+	as, data, err := kcodec.ReadValues[A](data)
+	if err != nil {
+		return result, err
+	}
+	bs, data, err := kcodec.ReadValues[B](data)
+	if err != nil {
+		return result, err
+	}
+	if len(as) != 1 || len(bs) != 1 || len(data) != 0 {
+		return result, errors.New("malformed pair")
+	}
+	return NewPair(as[0], bs[0]), nil
+}
@@ -0,0 +1,47 @@
+package kp
+
+import (
+	"errors"
+	"testing"
+)
+
+func FuzzPairBinary(f *testing.F) {
+	for _, p := range []Pair[int64, string]{{}, {-5, "a"}, {1 << 40, "hello"}} {
+		data, _ := p.MarshalBinary()
+		f.Add(p.A, p.B, data)
+	}
+
+	f.Fuzz(func(t *testing.T, a int64, b string, data []byte) {
+		p := NewPair(a, b)
+		encoded, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Pair[int64, string]
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded != p {
+			t.Fatalf("round trip changed %v to %v", p, decoded)
+		}
+
+		// Arbitrary bytes either decode to a pair that round trips or fail cleanly
+		var arbitrary Pair[int64, string]
+		if err := arbitrary.UnmarshalBinary(data); err != nil {
+			if !errors.Is(err, InvalidEncodingError) {
+				t.Fatalf("UnmarshalBinary error %v is not an InvalidEncodingError", err)
+			}
+		} else {
+			encoded, _ := arbitrary.MarshalBinary()
+			var again Pair[int64, string]
+			if err := again.UnmarshalBinary(encoded); err != nil || again != arbitrary {
+				t.Fatalf("%x decoded to %v, which round trips to %v, %v", data, arbitrary, again, err)
+			}
+		}
+
+		var gobbed Pair[[]int, map[string]int]
+		if err := gobbed.UnmarshalBinary(data); err != nil && !errors.Is(err, InvalidEncodingError) {
+			t.Fatalf("UnmarshalBinary error %v is not an InvalidEncodingError", err)
+		}
+	})
+}
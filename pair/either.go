@@ -0,0 +1,78 @@
+package kp
+
+import "fmt"
+
+// Either holds exactly one of two values: a Left of type L or a Right of type R.
+// By convention Right is the expected value and Left the alternative. The zero value is a Left holding the zero L.
+type Either[L any, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left creates an Either holding a left value
+func Left[L any, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right creates an Either holding a right value
+func Right[L any, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// IsLeft returns true if the Either holds a left value
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight returns true if the Either holds a right value
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// GetLeft returns the left value, or false if the Either holds a right value
+func (e Either[L, R]) GetLeft() (L, bool) {
+	return e.left, !e.isRight
+}
+
+// GetRight returns the right value, or false if the Either holds a left value
+func (e Either[L, R]) GetRight() (R, bool) {
+	return e.right, e.isRight
+}
+
+// Swap returns an Either with the sides exchanged
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// String returns Left(value) or Right(value)
+func (e Either[L, R]) String() string {
+	if e.isRight {
+		return fmt.Sprintf("Right(%v)", e.right)
+	}
+	return fmt.Sprintf("Left(%v)", e.left)
+}
+
+// Fold returns onLeft applied to the left value or onRight applied to the right value
+func Fold[L any, R any, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// MapLeft applies f to the left value, if the Either holds one
+func MapLeft[L any, R any, T any](e Either[L, R], f func(L) T) Either[T, R] {
+	if e.isRight {
+		return Right[T](e.right)
+	}
+	return Left[T, R](f(e.left))
+}
+
+// MapRight applies f to the right value, if the Either holds one
+func MapRight[L any, R any, T any](e Either[L, R], f func(R) T) Either[L, T] {
+	if e.isRight {
+		return Right[L](f(e.right))
+	}
+	return Left[L, T](e.left)
+}
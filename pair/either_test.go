@@ -0,0 +1,66 @@
+package kp
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestEither(t *testing.T) {
+	left := Left[error, int](errors.New("bad"))
+	right := Right[error](7)
+	var zero Either[string, int]
+
+	if !left.IsLeft() || left.IsRight() || right.IsLeft() || !right.IsRight() || !zero.IsLeft() {
+		t.Fatal("IsLeft or IsRight gave the wrong answer")
+	}
+	if v, ok := right.GetRight(); v != 7 || !ok {
+		t.Errorf("GetRight = %d, %v", v, ok)
+	}
+	if _, ok := right.GetLeft(); ok {
+		t.Error("GetLeft of a Right returned true")
+	}
+	if v, ok := left.GetLeft(); v == nil || v.Error() != "bad" || !ok {
+		t.Errorf("GetLeft = %v, %v", v, ok)
+	}
+	if _, ok := left.GetRight(); ok {
+		t.Error("GetRight of a Left returned true")
+	}
+	if v, ok := zero.GetLeft(); v != "" || !ok {
+		t.Errorf("zero value GetLeft = %q, %v, want the zero L", v, ok)
+	}
+	if left.String() != "Left(bad)" || right.String() != "Right(7)" {
+		t.Errorf("String = %s, %s", left, right)
+	}
+
+	swapped := right.Swap()
+	if v, ok := swapped.GetLeft(); v != 7 || !ok || swapped.Swap() != right {
+		t.Errorf("Swap = %v, want Left(7) and back", swapped)
+	}
+}
+
+func TestEitherCombinators(t *testing.T) {
+	describe := func(e Either[string, int]) string {
+		return Fold(e, func(s string) string { return "error: " + s }, strconv.Itoa)
+	}
+	if got := describe(Right[string](3)); got != "3" {
+		t.Errorf("Fold(Right) = %q", got)
+	}
+	if got := describe(Left[string, int]("x")); got != "error: x" {
+		t.Errorf("Fold(Left) = %q", got)
+	}
+
+	double := func(n int) int { return n * 2 }
+	if got := MapRight(Right[string](3), double); got != Right[string](6) {
+		t.Errorf("MapRight(Right) = %v", got)
+	}
+	if got := MapRight(Left[string, int]("x"), double); got != Left[string, int]("x") {
+		t.Errorf("MapRight(Left) = %v, want it unchanged", got)
+	}
+	if got := MapLeft(Left[string, int]("x"), func(s string) int { return len(s) }); got != Left[int, int](1) {
+		t.Errorf("MapLeft(Left) = %v", got)
+	}
+	if got := MapLeft(Right[string](3), func(s string) int { return len(s) }); got != Right[int](3) {
+		t.Errorf("MapLeft(Right) = %v, want it unchanged", got)
+	}
+}
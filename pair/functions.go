@@ -0,0 +1,117 @@
+package kp
+
+import kl "github.com/KeylimeVI/keylime-go/list"
+
+// PartitionBy splits the slice into the elements for which predicate returns true (A) and the rest (B), keeping their order
+func PartitionBy[T any, S ~[]T](list S, predicate func(T) bool) Pair[kl.List[T], kl.List[T]] {
+	matching, rest := kl.NewList[T](), kl.NewList[T]()
+	for _, item := range list {
+		if predicate(item) {
+			matching.Add(item)
+		} else {
+			rest.Add(item)
+		}
+	}
+	return NewPair(matching, rest)
+}
+
+// Zip pairs up the elements of a and b by index, stopping at the end of the shorter slice
+func Zip[A any, B any, SA ~[]A, SB ~[]B](a SA, b SB) kl.List[Pair[A, B]] {
+	n := min(len(a), len(b))
+	result := make(kl.List[Pair[A, B]], n)
+	for i := 0; i < n; i++ {
+		result[i] = NewPair(a[i], b[i])
+	}
+	return result
+}
+
+// ZipLongest pairs up the elements of a and b by index, padding the shorter slice with fillA or fillB
+func ZipLongest[A any, B any, SA ~[]A, SB ~[]B](a SA, b SB, fillA A, fillB B) kl.List[Pair[A, B]] {
+	n := max(len(a), len(b))
+	result := make(kl.List[Pair[A, B]], n)
+	for i := 0; i < n; i++ {
+		p := NewPair(fillA, fillB)
+		if i < len(a) {
+			p.A = a[i]
+		}
+		if i < len(b) {
+			p.B = b[i]
+		}
+		result[i] = p
+	}
+	return result
+}
+
+// Unzip splits a slice of pairs into a list of the first values and a list of the second values
+func Unzip[A any, B any, S ~[]Pair[A, B]](pairs S) (kl.List[A], kl.List[B]) {
+	as := make(kl.List[A], len(pairs))
+	bs := make(kl.List[B], len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.Unwrap()
+	}
+	return as, bs
+}
+
+// Enumerate pairs each element of the slice with its index
+func Enumerate[T any, S ~[]T](list S) kl.List[Pair[int, T]] {
+	result := make(kl.List[Pair[int, T]], len(list))
+	for i, item := range list {
+		result[i] = NewPair(i, item)
+	}
+	return result
+}
+
+// Pairwise returns each element of the slice paired with the element after it
+func Pairwise[T any, S ~[]T](list S) kl.List[Pair[T, T]] {
+	if len(list) < 2 {
+		return kl.NewList[Pair[T, T]]()
+	}
+	result := make(kl.List[Pair[T, T]], len(list)-1)
+	for i := range result {
+		result[i] = NewPair(list[i], list[i+1])
+	}
+	return result
+}
+
+// Zip3 groups the elements of a, b and c by index, stopping at the end of the shortest slice
+func Zip3[A any, B any, C any, SA ~[]A, SB ~[]B, SC ~[]C](a SA, b SB, c SC) kl.List[Triple[A, B, C]] {
+	n := min(len(a), len(b), len(c))
+	result := make(kl.List[Triple[A, B, C]], n)
+	for i := 0; i < n; i++ {
+		result[i] = NewTriple(a[i], b[i], c[i])
+	}
+	return result
+}
+
+// Zip4 groups the elements of a, b, c and d by index, stopping at the end of the shortest slice
+func Zip4[A any, B any, C any, D any, SA ~[]A, SB ~[]B, SC ~[]C, SD ~[]D](a SA, b SB, c SC, d SD) kl.List[Quad[A, B, C, D]] {
+	n := min(len(a), len(b), len(c), len(d))
+	result := make(kl.List[Quad[A, B, C, D]], n)
+	for i := 0; i < n; i++ {
+		result[i] = NewQuad(a[i], b[i], c[i], d[i])
+	}
+	return result
+}
+
+// Unzip3 splits a slice of triples into a list of each of their values
+func Unzip3[A any, B any, C any, S ~[]Triple[A, B, C]](triples S) (kl.List[A], kl.List[B], kl.List[C]) {
+	as := make(kl.List[A], len(triples))
+	bs := make(kl.List[B], len(triples))
+	cs := make(kl.List[C], len(triples))
+	for i, t := range triples {
+		as[i], bs[i], cs[i] = t.Unwrap()
+	}
+	return as, bs, cs
+}
+
+// Unzip4 splits a slice of quads into a list of each of their values
+func Unzip4[A any, B any, C any, D any, S ~[]Quad[A, B, C, D]](quads S) (kl.List[A], kl.List[B], kl.List[C], kl.List[D]) {
+	as := make(kl.List[A], len(quads))
+	bs := make(kl.List[B], len(quads))
+	cs := make(kl.List[C], len(quads))
+	ds := make(kl.List[D], len(quads))
+	for i, q := range quads {
+		as[i], bs[i], cs[i], ds[i] = q.Unwrap()
+	}
+	return as, bs, cs, ds
+}
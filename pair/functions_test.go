@@ -0,0 +1,53 @@
+package kp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPartitionBy(t *testing.T) {
+	parts := PartitionBy([]int{5, 2, 8, 1, 4}, func(n int) bool { return n%2 == 0 })
+	if !slices.Equal(parts.A, []int{2, 8, 4}) || !slices.Equal(parts.B, []int{5, 1}) {
+		t.Fatalf("PartitionBy = %v, want [2 8 4] and [5 1]", parts)
+	}
+	if empty := PartitionBy([]int(nil), func(int) bool { return true }); len(empty.A) != 0 || len(empty.B) != 0 {
+		t.Errorf("PartitionBy of nil = %v", empty)
+	}
+}
+
+func TestZip(t *testing.T) {
+	a, b := []int{1, 2, 3}, []string{"x", "y"}
+	if got, want := Zip(a, b), []Pair[int, string]{{1, "x"}, {2, "y"}}; !slices.Equal(got, want) {
+		t.Errorf("Zip = %v, want %v", got, want)
+	}
+	if got, want := ZipLongest(a, b, 0, "-"), []Pair[int, string]{{1, "x"}, {2, "y"}, {3, "-"}}; !slices.Equal(got, want) {
+		t.Errorf("ZipLongest = %v, want %v", got, want)
+	}
+	if got, want := ZipLongest(a[:1], b, -1, "-"), []Pair[int, string]{{1, "x"}, {-1, "y"}}; !slices.Equal(got, want) {
+		t.Errorf("ZipLongest with a shorter = %v, want %v", got, want)
+	}
+	if got := Zip([]int(nil), b); len(got) != 0 {
+		t.Errorf("Zip with nil = %v", got)
+	}
+
+	as, bs := Unzip(Zip(a, b))
+	if !slices.Equal(as, []int{1, 2}) || !slices.Equal(bs, []string{"x", "y"}) {
+		t.Errorf("Unzip(Zip) = %v, %v", as, bs)
+	}
+	as, bs = Unzip([]Pair[int, string](nil))
+	if len(as) != 0 || len(bs) != 0 {
+		t.Errorf("Unzip of nil = %v, %v", as, bs)
+	}
+}
+
+func TestEnumeratePairwise(t *testing.T) {
+	if got, want := Enumerate([]string{"a", "b"}), []Pair[int, string]{{0, "a"}, {1, "b"}}; !slices.Equal(got, want) {
+		t.Errorf("Enumerate = %v, want %v", got, want)
+	}
+	if got, want := Pairwise([]int{1, 2, 4}), []Pair[int, int]{{1, 2}, {2, 4}}; !slices.Equal(got, want) {
+		t.Errorf("Pairwise = %v, want %v", got, want)
+	}
+	if got := Pairwise([]int{1}); len(got) != 0 {
+		t.Errorf("Pairwise of one element = %v, want an empty list", got)
+	}
+}
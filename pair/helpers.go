@@ -0,0 +1,8 @@
+package kp
+
+import "errors"
+
+// Exported sentinel errors
+var (
+	InvalidEncodingError = errors.New("invalid encoding")
+)
@@ -0,0 +1,98 @@
+package kp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// KeyedPair is a Pair that marshals to a JSON object with configurable keys instead of an array, see Pair.WithKeys
+type KeyedPair[A any, B any] struct {
+	Pair[A, B]
+	KeyA string
+	KeyB string
+}
+
+// WithKeys wraps the pair so it marshals as {"keyA": A, "keyB": B}
+func (p Pair[A, B]) WithKeys(keyA string, keyB string) KeyedPair[A, B] {
+	return KeyedPair[A, B]{Pair: p, KeyA: keyA, KeyB: keyB}
+}
+
+// MarshalJSON encodes the pair as a 2-element JSON array [A, B]
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{p.A, p.B})
+}
+
+// UnmarshalJSON decodes a 2-element JSON array, or the legacy {"A": .., "B": ..} object, into the pair
+//
+// Errors: InvalidEncodingError
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		return unmarshalObject(data, "A", "B", p)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("%w: pair array has %d elements", InvalidEncodingError, len(raw))
+	}
+	var result Pair[A, B]
+	if err := json.Unmarshal(raw[0], &result.A); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw[1], &result.B); err != nil {
+		return err
+	}
+	*p = result
+	return nil
+}
+
+// MarshalJSON encodes the pair as an object with the configured keys
+func (p KeyedPair[A, B]) MarshalJSON() ([]byte, error) {
+	a, err := json.Marshal(p.A)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(p.B)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]json.RawMessage{p.KeyA: a, p.KeyB: b})
+}
+
+// UnmarshalJSON decodes an object using the configured keys, which must be set beforehand
+//
+// Errors: InvalidEncodingError
+func (p *KeyedPair[A, B]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	return unmarshalObject(data, p.KeyA, p.KeyB, &p.Pair)
+}
+
+func unmarshalObject[A any, B any](data []byte, keyA string, keyB string, p *Pair[A, B]) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	rawA, okA := fields[keyA]
+	rawB, okB := fields[keyB]
+	if !okA || !okB {
+		return fmt.Errorf("%w: pair object needs keys %q and %q", InvalidEncodingError, keyA, keyB)
+	}
+	var result Pair[A, B]
+	if err := json.Unmarshal(rawA, &result.A); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(rawB, &result.B); err != nil {
+		return err
+	}
+	*p = result
+	return nil
+}
@@ -0,0 +1,72 @@
+package kp
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPairJSON(t *testing.T) {
+	p := NewPair("id", 7)
+	data, err := json.Marshal(p)
+	if err != nil || string(data) != `["id",7]` {
+		t.Fatalf("Marshal = %s, %v, want [\"id\",7]", data, err)
+	}
+
+	for _, c := range []struct {
+		name  string
+		input string
+	}{
+		{"array", `["id", 7]`},
+		{"legacy object", `{"A": "id", "B": 7}`},
+		{"padded legacy object", " \n{\"B\": 7, \"A\": \"id\"}"},
+	} {
+		var got Pair[string, int]
+		if err := json.Unmarshal([]byte(c.input), &got); err != nil || got != p {
+			t.Errorf("%s: Unmarshal = %v, %v, want %v", c.name, got, err, p)
+		}
+	}
+
+	for _, input := range []string{`["id"]`, `["id", 7, 8]`, `{"A": "id"}`} {
+		var got Pair[string, int]
+		if err := json.Unmarshal([]byte(input), &got); !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("Unmarshal(%s) = %v, want InvalidEncodingError", input, err)
+		}
+	}
+	for _, input := range []string{`[7, "id"]`, `"id"`} {
+		var got Pair[string, int]
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded with %v", input, got)
+		}
+	}
+
+	unchanged := p
+	if err := json.Unmarshal([]byte(`null`), &unchanged); err != nil || unchanged != p {
+		t.Errorf("Unmarshal(null) = %v, %v, want the pair unchanged", unchanged, err)
+	}
+
+	nested := []Pair[int, Pair[string, bool]]{NewPair(1, NewPair("x", true))}
+	data, _ = json.Marshal(nested)
+	if string(data) != `[[1,["x",true]]]` {
+		t.Errorf("Marshal of nested pairs = %s", data)
+	}
+}
+
+func TestKeyedPairJSON(t *testing.T) {
+	keyed := NewPair("id", 7).WithKeys("name", "count")
+	data, err := json.Marshal(keyed)
+	if err != nil || string(data) != `{"count":7,"name":"id"}` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+
+	decoded := Pair[string, int]{}.WithKeys("name", "count")
+	if err := json.Unmarshal([]byte(`{"name": "x", "count": 3, "extra": 1}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Pair != NewPair("x", 3) || decoded.KeyA != "name" || decoded.KeyB != "count" {
+		t.Errorf("Unmarshal = %+v, want (x, 3) with the keys kept", decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"A": "x", "B": 3}`), &decoded); !errors.Is(err, InvalidEncodingError) {
+		t.Errorf("Unmarshal with the wrong keys = %v, want InvalidEncodingError", err)
+	}
+}
@@ -0,0 +1,68 @@
+package kp
+
+import "cmp"
+
+// Triple is a generic type that holds three values
+type Triple[A any, B any, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// NewTriple creates a new triple of three values
+func NewTriple[A any, B any, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{A: a, B: b, C: c}
+}
+
+// Unwrap returns the values the triple is holding
+func (t *Triple[A, B, C]) Unwrap() (A, B, C) {
+	return t.A, t.B, t.C
+}
+
+// Quad is a generic type that holds four values
+type Quad[A any, B any, C any, D any] struct {
+	A A
+	B B
+	C C
+	D D
+}
+
+// NewQuad creates a new quad of four values
+func NewQuad[A any, B any, C any, D any](a A, b B, c C, d D) Quad[A, B, C, D] {
+	return Quad[A, B, C, D]{A: a, B: b, C: c, D: d}
+}
+
+// Unwrap returns the values the quad is holding
+func (q *Quad[A, B, C, D]) Unwrap() (A, B, C, D) {
+	return q.A, q.B, q.C, q.D
+}
+
+// Swap returns a pair with the values in the other order
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return NewPair(p.B, p.A)
+}
+
+// MapFirst applies f to the first value of the pair
+func MapFirst[A any, B any, C any](p Pair[A, B], f func(A) C) Pair[C, B] {
+	return NewPair(f(p.A), p.B)
+}
+
+// MapSecond applies f to the second value of the pair
+func MapSecond[A any, B any, C any](p Pair[A, B], f func(B) C) Pair[A, C] {
+	return NewPair(p.A, f(p.B))
+}
+
+// Compare orders pairs lexicographically: by A, then by B. It can be passed to kl.SortFunc or used as a kl.Comparator.
+func Compare[A cmp.Ordered, B cmp.Ordered](x Pair[A, B], y Pair[A, B]) int {
+	return cmp.Or(cmp.Compare(x.A, y.A), cmp.Compare(x.B, y.B))
+}
+
+// CompareTriple orders triples lexicographically, see Compare
+func CompareTriple[A cmp.Ordered, B cmp.Ordered, C cmp.Ordered](x Triple[A, B, C], y Triple[A, B, C]) int {
+	return cmp.Or(cmp.Compare(x.A, y.A), cmp.Compare(x.B, y.B), cmp.Compare(x.C, y.C))
+}
+
+// CompareQuad orders quads lexicographically, see Compare
+func CompareQuad[A cmp.Ordered, B cmp.Ordered, C cmp.Ordered, D cmp.Ordered](x Quad[A, B, C, D], y Quad[A, B, C, D]) int {
+	return cmp.Or(cmp.Compare(x.A, y.A), cmp.Compare(x.B, y.B), cmp.Compare(x.C, y.C), cmp.Compare(x.D, y.D))
+}
@@ -0,0 +1,74 @@
+package kp
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+func TestCompare(t *testing.T) {
+	pairs := kl.List[Pair[string, int]]{{"b", 1}, {"a", 2}, {"b", 0}, {"a", 1}, {"", 5}}
+	pairs.SortFunc(Compare[string, int])
+	want := []Pair[string, int]{{"", 5}, {"a", 1}, {"a", 2}, {"b", 0}, {"b", 1}}
+	if !slices.Equal(pairs, want) {
+		t.Fatalf("sorted by Compare = %v, want %v", pairs, want)
+	}
+	for _, c := range []struct {
+		x, y Pair[int, int]
+		want int
+	}{
+		{NewPair(1, 9), NewPair(2, 0), -1},
+		{NewPair(2, 0), NewPair(1, 9), 1},
+		{NewPair(1, 1), NewPair(1, 2), -1},
+		{NewPair(1, 2), NewPair(1, 2), 0},
+	} {
+		if got := Compare(c.x, c.y); got != c.want {
+			t.Errorf("Compare(%v, %v) = %d, want %d", c.x, c.y, got, c.want)
+		}
+	}
+
+	if CompareTriple(NewTriple(1, "a", 2.0), NewTriple(1, "a", 1.5)) != 1 || CompareTriple(NewTriple(0, "z", 9.0), NewTriple(1, "a", 0.0)) != -1 {
+		t.Error("CompareTriple does not order by A, then B, then C")
+	}
+	if CompareQuad(NewQuad(1, 1, 1, 1), NewQuad(1, 1, 1, 2)) != -1 || CompareQuad(NewQuad(1, 1, 1, 1), NewQuad(1, 1, 1, 1)) != 0 {
+		t.Error("CompareQuad does not reach D")
+	}
+}
+
+func TestPairMapping(t *testing.T) {
+	p := NewPair(3, "x")
+	if got := p.Swap(); got != NewPair("x", 3) {
+		t.Errorf("Swap = %v", got)
+	}
+	if got := MapFirst(p, strconv.Itoa); got != NewPair("3", "x") {
+		t.Errorf("MapFirst = %v", got)
+	}
+	if got := MapSecond(p, func(s string) int { return len(s) }); got != NewPair(3, 1) {
+		t.Errorf("MapSecond = %v", got)
+	}
+}
+
+func TestZip3(t *testing.T) {
+	triples := Zip3([]int{1, 2, 3}, []string{"a", "b"}, []bool{true, false, true})
+	if want := []Triple[int, string, bool]{{1, "a", true}, {2, "b", false}}; !slices.Equal(triples, want) {
+		t.Fatalf("Zip3 = %v, want %v", triples, want)
+	}
+	as, bs, cs := Unzip3(triples)
+	if !slices.Equal(as, []int{1, 2}) || !slices.Equal(bs, []string{"a", "b"}) || !slices.Equal(cs, []bool{true, false}) {
+		t.Errorf("Unzip3 = %v, %v, %v", as, bs, cs)
+	}
+	if got := Zip3([]int{1}, []int(nil), []int{1}); len(got) != 0 {
+		t.Errorf("Zip3 with nil = %v", got)
+	}
+
+	quads := Zip4([]int{1, 2}, []int{3, 4}, []int{5, 6}, []int{7})
+	if want := []Quad[int, int, int, int]{{1, 3, 5, 7}}; !slices.Equal(quads, want) {
+		t.Fatalf("Zip4 = %v, want %v", quads, want)
+	}
+	a, b, c, d := Unzip4(quads)
+	if !slices.Equal(a, []int{1}) || !slices.Equal(b, []int{3}) || !slices.Equal(c, []int{5}) || !slices.Equal(d, []int{7}) {
+		t.Errorf("Unzip4 = %v, %v, %v, %v", a, b, c, d)
+	}
+}
@@ -0,0 +1,39 @@
+package ks
+
+import (
+	"fmt"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Elements of ordered types are written sorted, so sets of integers get the compact delta encoding.
+func (s Set[T]) MarshalBinary() ([]byte, error) {
+	items := s.ToSlice()
+	kcodec.SortOrdered(items)
+	return kcodec.Marshal('s', items)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the set
+//
+// Errors: InvalidEncodingError
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	items, err := kcodec.Unmarshal[T]('s', data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", InvalidEncodingError, err)
+	}
+	*s = NewSetCap(len(items), items...)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary format
+func (s Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder
+//
+// Errors: InvalidEncodingError
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,74 @@
+package ks
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+type binaryRecord struct {
+	Name  string
+	Score int
+}
+
+// checkDecode unmarshals arbitrary data, which must either fail with InvalidEncodingError
+// or decode to a set that round trips and has no more items than there were bytes
+func checkDecode[T comparable](t *testing.T, data []byte) {
+	var s Set[T]
+	if err := s.UnmarshalBinary(data); err != nil {
+		if !errors.Is(err, InvalidEncodingError) {
+			t.Fatalf("UnmarshalBinary error %v is not an InvalidEncodingError", err)
+		}
+		return
+	}
+	if s.Len() > len(data) {
+		t.Fatalf("decoded %d items from %d bytes", s.Len(), len(data))
+	}
+	encoded, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again Set[T]
+	if err := again.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("re-decoding %x: %v", encoded, err)
+	}
+	if !s.Equals(again) {
+		t.Fatalf("round trip changed %v to %v", s, again)
+	}
+}
+
+func FuzzSetBinary(f *testing.F) {
+	for _, items := range [][]int64{nil, {1}, {5, 3, 9}, {-10, -2, 0, 7, 1 << 40}} {
+		data, _ := NewSet(items...).MarshalBinary()
+		f.Add(data)
+	}
+	data, _ := NewSet("a", "", "ccc").MarshalBinary()
+	f.Add(data)
+	data, _ = NewSet(binaryRecord{"a", 1}).MarshalBinary()
+	f.Add(data)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Integer sets are written sorted, so this round trips through the delta encoding
+		s := NewSet[int64]()
+		for rest := data; len(rest) >= 8; rest = rest[8:] {
+			s.Add(int64(binary.LittleEndian.Uint64(rest)))
+		}
+		encoded, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Set[int64]
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatal(err)
+		}
+		if !s.Equals(decoded) {
+			t.Fatalf("round trip changed %v to %v", s, decoded)
+		}
+
+		checkDecode[int64](t, data)
+		checkDecode[uint32](t, data)
+		checkDecode[string](t, data)
+		checkDecode[bool](t, data)
+		checkDecode[binaryRecord](t, data)
+	})
+}
@@ -0,0 +1,210 @@
+package ks
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const bloomEncodingVersion = 1
+
+// maxBloomHashes bounds the number of hash functions, more than any representable false positive rate needs
+const maxBloomHashes = 1 << 11
+
+// BloomFilter is a probabilistic set that never reports false negatives
+// and reports false positives at a configurable rate.
+type BloomFilter[T comparable] struct {
+	words []uint64
+	m     uint64 // number of bits
+	k     uint64 // number of hash functions
+	count uint64 // number of items added
+}
+
+// NewBloomFilter creates a Bloom filter sized to hold expectedItems with the given false positive rate.
+// Panics if falsePositiveRate is not in the open interval (0, 1).
+func NewBloomFilter[T comparable](expectedItems int, falsePositiveRate float64) *BloomFilter[T] {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("ks.NewBloomFilter: false positive rate must be in (0, 1)")
+	}
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	n := float64(expectedItems)
+	m := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := uint64(math.Round(float64(m) / n * math.Ln2))
+	k = min(max(k, 1), maxBloomHashes)
+	return &BloomFilter[T]{
+		words: make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+	}
+}
+
+// BloomFilterFromSet creates a Bloom filter sized for and seeded with the items of set
+func BloomFilterFromSet[T comparable](set Set[T], falsePositiveRate float64) *BloomFilter[T] {
+	b := NewBloomFilter[T](set.Len(), falsePositiveRate)
+	for item := range set {
+		b.Add(item)
+	}
+	return b
+}
+
+// BloomFilterFromList creates a Bloom filter sized for and seeded with the items of list
+func BloomFilterFromList[T comparable](list kl.List[T], falsePositiveRate float64) *BloomFilter[T] {
+	b := NewBloomFilter[T](list.Len(), falsePositiveRate)
+	b.Add(list...)
+	return b
+}
+
+// Add items to the filter
+//
+// Supports method chaining
+func (b *BloomFilter[T]) Add(items ...T) *BloomFilter[T] {
+	for _, item := range items {
+		h1, h2 := bloomHashes(item)
+		for i := uint64(0); i < b.k; i++ {
+			bit := (h1 + i*h2) % b.m
+			b.words[bit/64] |= 1 << (bit % 64)
+		}
+		b.count++
+	}
+	return b
+}
+
+// Contains returns true if every item may be in the filter.
+// A false result is definite, a true result is wrong with probability close to the configured rate.
+func (b *BloomFilter[T]) Contains(items ...T) bool {
+	for _, item := range items {
+		if !b.singleContains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns true if at least one of the items may be in the filter
+func (b *BloomFilter[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if b.singleContains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of items added to the filter, including duplicates
+func (b *BloomFilter[T]) Len() int {
+	return int(b.count)
+}
+
+// EstimatedLen estimates the number of distinct items in the filter from the number of set bits
+func (b *BloomFilter[T]) EstimatedLen() int {
+	set := b.setBits()
+	if set == b.m {
+		return int(b.count)
+	}
+	m, k := float64(b.m), float64(b.k)
+	return int(math.Round(-m / k * math.Log(1-float64(set)/m)))
+}
+
+// FalsePositiveRate estimates the current false positive rate from the number of set bits
+func (b *BloomFilter[T]) FalsePositiveRate() float64 {
+	return math.Pow(float64(b.setBits())/float64(b.m), float64(b.k))
+}
+
+// Clear all items from the filter
+func (b *BloomFilter[T]) Clear() *BloomFilter[T] {
+	clear(b.words)
+	b.count = 0
+	return b
+}
+
+// Copy returns a new independent copy of the filter
+func (b *BloomFilter[T]) Copy() *BloomFilter[T] {
+	c := *b
+	c.words = make([]uint64, len(b.words))
+	copy(c.words, b.words)
+	return &c
+}
+
+// Merge adds all items of other into the filter. Both filters must have been created with the same parameters.
+//
+// Errors: IncompatibleSketchError
+func (b *BloomFilter[T]) Merge(other *BloomFilter[T]) error {
+	if b.m != other.m || b.k != other.k {
+		return IncompatibleSketchError
+	}
+	for i := range b.words {
+		b.words[i] |= other.words[i]
+	}
+	b.count += other.count
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+3*8+len(b.words)*8)
+	data = append(data, 'B', bloomEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	data = binary.LittleEndian.AppendUint64(data, b.k)
+	data = binary.LittleEndian.AppendUint64(data, b.count)
+	for _, word := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2+3*8 || data[0] != 'B' || data[1] != bloomEncodingVersion {
+		return InvalidEncodingError
+	}
+	data = data[2:]
+	m := binary.LittleEndian.Uint64(data)
+	k := binary.LittleEndian.Uint64(data[8:])
+	count := binary.LittleEndian.Uint64(data[16:])
+	data = data[24:]
+	// m is checked against the data first so the word count cannot overflow
+	if m == 0 || m > uint64(len(data))*8 || uint64(len(data)) != (m+63)/64*8 || k == 0 || k > maxBloomHashes {
+		return InvalidEncodingError
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	*b = BloomFilter[T]{words: words, m: m, k: k, count: count}
+	return nil
+}
+
+func (b *BloomFilter[T]) singleContains(item T) bool {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BloomFilter[T]) setBits() uint64 {
+	total := 0
+	for _, word := range b.words {
+		total += bits.OnesCount64(word)
+	}
+	return uint64(total)
+}
+
+// bloomHashes derives the two base hashes for Kirsch-Mitzenmacher double hashing
+func bloomHashes[T comparable](item T) (uint64, uint64) {
+	h1 := hashOf(item)
+	h2 := mix64(h1) | 1
+	return h1, h2
+}
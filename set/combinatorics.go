@@ -0,0 +1,32 @@
+package ks
+
+import (
+	"iter"
+	"math/big"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// PowerSet returns an iterator over every subset of the set, from the empty set up to a copy of the whole set.
+// Subsets are produced by increasing size, and subsets of the same size in lexicographic order of their items.
+// For integer, float and string types the items are sorted, as in Format; for other types they follow
+// the set's iteration order, which is random but fixed when iteration starts. Each yielded Set is new.
+func PowerSet[T comparable](set Set[T]) iter.Seq[Set[T]] {
+	return func(yield func(Set[T]) bool) {
+		items := set.ToSlice()
+		kcodec.SortOrdered(items)
+		for size := 0; size <= len(items); size++ {
+			for combination := range kl.Combinations(items, size) {
+				if !yield(NewSet(combination...)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CountPowerSet returns the number of subsets PowerSet yields for the set, 2^n
+func CountPowerSet[T comparable](set Set[T]) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(set.Len()))
+}
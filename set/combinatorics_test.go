@@ -0,0 +1,31 @@
+package ks
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPowerSet(t *testing.T) {
+	var got []string
+	for subset := range PowerSet(NewSet(3, 1, 2)) {
+		got = append(got, fmt.Sprint(subset))
+	}
+	want := []string{"[]", "[1]", "[2]", "[3]", "[1 2]", "[1 3]", "[2 3]", "[1 2 3]"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("PowerSet = %v, want %v", got, want)
+	}
+
+	for n := range 8 {
+		set := NewSet[int]()
+		for i := range n {
+			set.Add(i)
+		}
+		yielded := int64(0)
+		for range PowerSet(set) {
+			yielded++
+		}
+		if want := CountPowerSet(set).Int64(); yielded != want {
+			t.Errorf("PowerSet of %d items yielded %d, want %d", n, yielded, want)
+		}
+	}
+}
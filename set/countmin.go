@@ -0,0 +1,144 @@
+package ks
+
+import (
+	"encoding/binary"
+	"math"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const countMinEncodingVersion = 1
+
+// CountMinSketch estimates how often items occur in a stream using fixed memory.
+// Estimates never undercount, and overcount by at most epsilon * Total() with probability 1 - delta.
+type CountMinSketch[T comparable] struct {
+	counters []uint64
+	width    uint64
+	depth    uint64
+	total    uint64
+}
+
+// NewCountMinSketch creates a Count-Min sketch with the given error bound and failure probability.
+// Panics if epsilon or delta is not in the open interval (0, 1).
+func NewCountMinSketch[T comparable](epsilon float64, delta float64) *CountMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		panic("ks.NewCountMinSketch: epsilon and delta must be in (0, 1)")
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return &CountMinSketch[T]{
+		counters: make([]uint64, width*depth),
+		width:    width,
+		depth:    depth,
+	}
+}
+
+// CountMinSketchFromList creates a Count-Min sketch seeded with the items of list
+func CountMinSketchFromList[T comparable](list kl.List[T], epsilon float64, delta float64) *CountMinSketch[T] {
+	c := NewCountMinSketch[T](epsilon, delta)
+	c.Add(list...)
+	return c
+}
+
+// Add counts one occurrence of each item
+//
+// Supports method chaining
+func (c *CountMinSketch[T]) Add(items ...T) *CountMinSketch[T] {
+	for _, item := range items {
+		c.AddCount(item, 1)
+	}
+	return c
+}
+
+// AddCount counts count occurrences of item
+//
+// Supports method chaining
+func (c *CountMinSketch[T]) AddCount(item T, count uint64) *CountMinSketch[T] {
+	h1, h2 := bloomHashes(item)
+	for row := uint64(0); row < c.depth; row++ {
+		c.counters[row*c.width+(h1+row*h2)%c.width] += count
+	}
+	c.total += count
+	return c
+}
+
+// Estimate returns the estimated number of occurrences of item
+func (c *CountMinSketch[T]) Estimate(item T) uint64 {
+	h1, h2 := bloomHashes(item)
+	estimate := uint64(math.MaxUint64)
+	for row := uint64(0); row < c.depth; row++ {
+		estimate = min(estimate, c.counters[row*c.width+(h1+row*h2)%c.width])
+	}
+	return estimate
+}
+
+// Total returns the number of occurrences counted
+func (c *CountMinSketch[T]) Total() uint64 {
+	return c.total
+}
+
+// Clear all counts from the sketch
+func (c *CountMinSketch[T]) Clear() *CountMinSketch[T] {
+	clear(c.counters)
+	c.total = 0
+	return c
+}
+
+// Copy returns a new independent copy of the sketch
+func (c *CountMinSketch[T]) Copy() *CountMinSketch[T] {
+	result := *c
+	result.counters = make([]uint64, len(c.counters))
+	copy(result.counters, c.counters)
+	return &result
+}
+
+// Merge adds all counts of other into the sketch. Both sketches must have been created with the same parameters.
+//
+// Errors: IncompatibleSketchError
+func (c *CountMinSketch[T]) Merge(other *CountMinSketch[T]) error {
+	if c.width != other.width || c.depth != other.depth {
+		return IncompatibleSketchError
+	}
+	for i, count := range other.counters {
+		c.counters[i] += count
+	}
+	c.total += other.total
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c *CountMinSketch[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+3*8+len(c.counters)*8)
+	data = append(data, 'M', countMinEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, c.width)
+	data = binary.LittleEndian.AppendUint64(data, c.depth)
+	data = binary.LittleEndian.AppendUint64(data, c.total)
+	for _, count := range c.counters {
+		data = binary.LittleEndian.AppendUint64(data, count)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (c *CountMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2+3*8 || data[0] != 'M' || data[1] != countMinEncodingVersion {
+		return InvalidEncodingError
+	}
+	width := binary.LittleEndian.Uint64(data[2:])
+	depth := binary.LittleEndian.Uint64(data[10:])
+	total := binary.LittleEndian.Uint64(data[18:])
+	data = data[26:]
+	// The dimensions are checked against the data first so their product cannot overflow
+	if width == 0 || depth == 0 || width > uint64(len(data))/8 || depth > uint64(len(data))/8/width ||
+		uint64(len(data)) != width*depth*8 {
+		return InvalidEncodingError
+	}
+	counters := make([]uint64, width*depth)
+	for i := range counters {
+		counters[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	*c = CountMinSketch[T]{counters: counters, width: width, depth: depth, total: total}
+	return nil
+}
@@ -0,0 +1,306 @@
+package ks
+
+import (
+	"encoding/binary"
+	"math/rand"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const (
+	cuckooEncodingVersion = 1
+	cuckooBucketSize      = 4
+	cuckooMaxKicks        = 500
+)
+
+type cuckooBucket [cuckooBucketSize]uint16
+
+// CuckooFilter is a probabilistic set like BloomFilter that also supports removing items.
+// It stores a 16-bit fingerprint per item, giving a false positive rate of roughly 0.01%.
+type CuckooFilter[T comparable] struct {
+	buckets []cuckooBucket
+	mask    uint64
+	count   uint64
+	// victim holds a fingerprint that could not be placed after the last failed insert.
+	// While it is set the filter is full.
+	victim      uint16
+	victimIndex uint64
+}
+
+// NewCuckooFilter creates a Cuckoo filter able to hold about capacity items
+func NewCuckooFilter[T comparable](capacity int) *CuckooFilter[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	// Aim for 95% load factor, the practical maximum for 4-way buckets
+	wanted := uint64(capacity)*100/95/cuckooBucketSize + 1
+	n := uint64(1)
+	for n < wanted {
+		n <<= 1
+	}
+	return &CuckooFilter[T]{
+		buckets: make([]cuckooBucket, n),
+		mask:    n - 1,
+	}
+}
+
+// CuckooFilterFromSet creates a Cuckoo filter sized for and seeded with the items of set
+//
+// Errors: FilterFullError
+func CuckooFilterFromSet[T comparable](set Set[T]) (*CuckooFilter[T], error) {
+	c := NewCuckooFilter[T](set.Len())
+	for item := range set {
+		if err := c.Add(item); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+// CuckooFilterFromList creates a Cuckoo filter sized for and seeded with the items of list
+//
+// Errors: FilterFullError
+func CuckooFilterFromList[T comparable](list kl.List[T]) (*CuckooFilter[T], error) {
+	c := NewCuckooFilter[T](list.Len())
+	return c, c.Add(list...)
+}
+
+// Add items to the filter, gives up and returns an error once the filter is full
+//
+// Errors: FilterFullError
+func (c *CuckooFilter[T]) Add(items ...T) error {
+	for _, item := range items {
+		f, i := c.locate(item)
+		if !c.insert(f, i) {
+			return FilterFullError
+		}
+	}
+	return nil
+}
+
+// Remove items from the filter, returns false if any of the items was not found.
+// Only remove items that were added, otherwise the fingerprint of a different item may be removed.
+func (c *CuckooFilter[T]) Remove(items ...T) bool {
+	removedAll := true
+	for _, item := range items {
+		f, i1 := c.locate(item)
+		i2 := c.altIndex(i1, f)
+		switch {
+		case c.removeFrom(i1, f), c.removeFrom(i2, f):
+			c.count--
+			c.reinsertVictim()
+		case c.victim == f && (c.victimIndex == i1 || c.victimIndex == i2):
+			c.victim = 0
+			c.count--
+		default:
+			removedAll = false
+		}
+	}
+	return removedAll
+}
+
+// Contains returns true if every item may be in the filter
+func (c *CuckooFilter[T]) Contains(items ...T) bool {
+	for _, item := range items {
+		if !c.singleContains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns true if at least one of the items may be in the filter
+func (c *CuckooFilter[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if c.singleContains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of items in the filter
+func (c *CuckooFilter[T]) Len() int {
+	return int(c.count)
+}
+
+// Cap returns the number of fingerprint slots in the filter
+func (c *CuckooFilter[T]) Cap() int {
+	return len(c.buckets) * cuckooBucketSize
+}
+
+// IsFull returns true if the last insert failed and no item has been removed since
+func (c *CuckooFilter[T]) IsFull() bool {
+	return c.victim != 0
+}
+
+// Clear all items from the filter
+func (c *CuckooFilter[T]) Clear() *CuckooFilter[T] {
+	clear(c.buckets)
+	c.count = 0
+	c.victim = 0
+	return c
+}
+
+// Copy returns a new independent copy of the filter
+func (c *CuckooFilter[T]) Copy() *CuckooFilter[T] {
+	result := *c
+	result.buckets = make([]cuckooBucket, len(c.buckets))
+	copy(result.buckets, c.buckets)
+	return &result
+}
+
+// Merge adds all items of other into the filter. Both filters must have the same capacity.
+//
+// Errors: IncompatibleSketchError, FilterFullError
+func (c *CuckooFilter[T]) Merge(other *CuckooFilter[T]) error {
+	if c.mask != other.mask {
+		return IncompatibleSketchError
+	}
+	for i, bucket := range other.buckets {
+		for _, f := range bucket {
+			if f != 0 && !c.insert(f, uint64(i)) {
+				return FilterFullError
+			}
+		}
+	}
+	if other.victim != 0 && !c.insert(other.victim, other.victimIndex) {
+		return FilterFullError
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c *CuckooFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+3*8+2+len(c.buckets)*cuckooBucketSize*2)
+	data = append(data, 'C', cuckooEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(c.buckets)))
+	data = binary.LittleEndian.AppendUint64(data, c.count)
+	data = binary.LittleEndian.AppendUint64(data, c.victimIndex)
+	data = binary.LittleEndian.AppendUint16(data, c.victim)
+	for _, bucket := range c.buckets {
+		for _, f := range bucket {
+			data = binary.LittleEndian.AppendUint16(data, f)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (c *CuckooFilter[T]) UnmarshalBinary(data []byte) error {
+	const headerLen = 2 + 3*8 + 2
+	if len(data) < headerLen || data[0] != 'C' || data[1] != cuckooEncodingVersion {
+		return InvalidEncodingError
+	}
+	n := binary.LittleEndian.Uint64(data[2:])
+	count := binary.LittleEndian.Uint64(data[10:])
+	victimIndex := binary.LittleEndian.Uint64(data[18:])
+	victim := binary.LittleEndian.Uint16(data[26:])
+	data = data[headerLen:]
+	if n == 0 || n&(n-1) != 0 || n > uint64(len(data))/(cuckooBucketSize*2) ||
+		uint64(len(data)) != n*cuckooBucketSize*2 || victimIndex >= n {
+		return InvalidEncodingError
+	}
+	buckets := make([]cuckooBucket, n)
+	for i := range buckets {
+		for j := range buckets[i] {
+			buckets[i][j] = binary.LittleEndian.Uint16(data)
+			data = data[2:]
+		}
+	}
+	*c = CuckooFilter[T]{buckets: buckets, mask: n - 1, count: count, victim: victim, victimIndex: victimIndex}
+	return nil
+}
+
+func (c *CuckooFilter[T]) singleContains(item T) bool {
+	f, i1 := c.locate(item)
+	i2 := c.altIndex(i1, f)
+	if c.buckets[i1].contains(f) || c.buckets[i2].contains(f) {
+		return true
+	}
+	return c.victim == f && (c.victimIndex == i1 || c.victimIndex == i2)
+}
+
+// locate returns the non-zero fingerprint and primary bucket index of item
+func (c *CuckooFilter[T]) locate(item T) (uint16, uint64) {
+	h := hashOf(item)
+	f := uint16(h >> 48)
+	if f == 0 {
+		f = 1
+	}
+	return f, h & c.mask
+}
+
+// altIndex is its own inverse, so either bucket of a fingerprint leads to the other
+func (c *CuckooFilter[T]) altIndex(i uint64, f uint16) uint64 {
+	return (i ^ mix64(uint64(f))) & c.mask
+}
+
+func (c *CuckooFilter[T]) insert(f uint16, i uint64) bool {
+	if c.victim != 0 {
+		return false
+	}
+	i2 := c.altIndex(i, f)
+	if c.buckets[i].add(f) || c.buckets[i2].add(f) {
+		c.count++
+		return true
+	}
+	if rand.Intn(2) == 1 {
+		i = i2
+	}
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := rand.Intn(cuckooBucketSize)
+		f, c.buckets[i][slot] = c.buckets[i][slot], f
+		i = c.altIndex(i, f)
+		if c.buckets[i].add(f) {
+			c.count++
+			return true
+		}
+	}
+	// The item being inserted is in the table now, the evicted fingerprint waits as victim
+	c.victim = f
+	c.victimIndex = i
+	c.count++
+	return true
+}
+
+func (c *CuckooFilter[T]) removeFrom(i uint64, f uint16) bool {
+	for j, stored := range c.buckets[i] {
+		if stored == f {
+			c.buckets[i][j] = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CuckooFilter[T]) reinsertVictim() {
+	if c.victim == 0 {
+		return
+	}
+	f, i := c.victim, c.victimIndex
+	c.victim = 0
+	c.count--
+	c.insert(f, i)
+}
+
+func (b *cuckooBucket) add(f uint16) bool {
+	for j, stored := range b {
+		if stored == 0 {
+			b[j] = f
+			return true
+		}
+	}
+	return false
+}
+
+func (b *cuckooBucket) contains(f uint16) bool {
+	for _, stored := range b {
+		if stored == f {
+			return true
+		}
+	}
+	return false
+}
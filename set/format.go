@@ -0,0 +1,45 @@
+package ks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// Format implements fmt.Formatter, formatting the set like a kl.List of its elements in sorted order.
+// Elements of ordered types are sorted by value, others by their %v then %#v representation, so output is stable.
+// %#v prints Go syntax with the set type, such as ks.Set[int]{1:struct {}{}, 2:struct {}{}}.
+func (s Set[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		kcodec.FormatGoSyntax(f, s, map[T]struct{}(s))
+		return
+	}
+	s.sorted().Format(f, verb)
+}
+
+// Truncated returns a view of the set that formats only its first limit elements in sorted order, see kl.List.Truncated
+func (s Set[T]) Truncated(limit int) kl.TruncatedList[T] {
+	return s.sorted().Truncated(limit)
+}
+
+func (s Set[T]) sorted() kl.List[T] {
+	items := s.ToSlice()
+	if kcodec.SortOrdered(items) {
+		return items
+	}
+	// Distinct elements can share a %v representation, %#v breaks most of those ties
+	text := make(map[T][2]string, len(items))
+	for _, item := range items {
+		text[item] = [2]string{fmt.Sprintf("%v", item), fmt.Sprintf("%#v", item)}
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		if c := strings.Compare(text[a][0], text[b][0]); c != 0 {
+			return c
+		}
+		return strings.Compare(text[a][1], text[b][1])
+	})
+	return items
+}
@@ -0,0 +1,35 @@
+package ks
+
+import (
+	"fmt"
+	"testing"
+)
+
+// sameText has distinct values that all print the same with %v
+type sameText struct {
+	id int
+}
+
+func (sameText) String() string { return "same" }
+
+func TestFormatGoSyntax(t *testing.T) {
+	if got, want := fmt.Sprintf("%#v", NewSet(2, 1)), "ks.Set[int]{1:struct {}{}, 2:struct {}{}}"; got != want {
+		t.Errorf("%%#v = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprintf("%#v", Set[int](nil)), "ks.Set[int](nil)"; got != want {
+		t.Errorf("%%#v = %s, want %s", got, want)
+	}
+}
+
+func TestFormatStableOrder(t *testing.T) {
+	if got := fmt.Sprint(NewSet(3, 1, 2)); got != "[1 2 3]" {
+		t.Errorf("Sprint = %q", got)
+	}
+
+	s := NewSet(sameText{3}, sameText{1}, sameText{2})
+	for range 50 {
+		if items := s.sorted(); items[0].id != 1 || items[1].id != 2 || items[2].id != 3 {
+			t.Fatalf("sorted = %#v, want ordered by %%#v", items)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package ks
 
+import kp "github.com/KeylimeVI/keylime-go/pair"
+
 // Reduce applies a reducer function over all elements of the set, starting from initial, and returns the accumulated result.
 // Note: iteration order over a set is undefined.
 func Reduce[T comparable, U any](set Set[T], initial U, reducer func(accumulator U, value T) U) U {
@@ -43,3 +45,14 @@ func Flatten[T comparable, C ~[]Set[T]](collection C) Set[T] {
 	}
 	return result
 }
+
+// CartesianProduct returns the set of all pairs taking the first value from a and the second from b
+func CartesianProduct[A comparable, B comparable](a Set[A], b Set[B]) Set[kp.Pair[A, B]] {
+	result := NewSetCap[kp.Pair[A, B]](len(a) * len(b))
+	for x := range a {
+		for y := range b {
+			result.Add(kp.NewPair(x, y))
+		}
+	}
+	return result
+}
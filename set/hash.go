@@ -0,0 +1,84 @@
+package ks
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// hashOf returns a 64-bit hash of item so sketches built from it can be serialized and merged on other machines.
+// Common scalar types are hashed from their bytes, anything else from its %#v representation,
+// which the package documentation warns is not stable across processes for types holding pointers.
+func hashOf[T comparable](item T) uint64 {
+	h := uint64(fnvOffset64)
+	switch v := any(item).(type) {
+	case string:
+		h = fnvString(h, v)
+	case int:
+		h = fnvUint64(h, uint64(v))
+	case int8:
+		h = fnvUint64(h, uint64(v))
+	case int16:
+		h = fnvUint64(h, uint64(v))
+	case int32:
+		h = fnvUint64(h, uint64(v))
+	case int64:
+		h = fnvUint64(h, uint64(v))
+	case uint:
+		h = fnvUint64(h, uint64(v))
+	case uint8:
+		h = fnvUint64(h, uint64(v))
+	case uint16:
+		h = fnvUint64(h, uint64(v))
+	case uint32:
+		h = fnvUint64(h, uint64(v))
+	case uint64:
+		h = fnvUint64(h, v)
+	case uintptr:
+		h = fnvUint64(h, uint64(v))
+	case float32:
+		h = fnvUint64(h, math.Float64bits(float64(v)))
+	case float64:
+		h = fnvUint64(h, math.Float64bits(v))
+	case bool:
+		if v {
+			h = fnvUint64(h, 1)
+		} else {
+			h = fnvUint64(h, 0)
+		}
+	default:
+		h = fnvString(h, fmt.Sprintf("%#v", v))
+	}
+	return mix64(h)
+}
+
+func fnvString(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+func fnvUint64(h uint64, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= fnvPrime64
+		v >>= 8
+	}
+	return h
+}
+
+// mix64 is the murmur3 finalizer, it spreads FNV's weak low bits across the whole word.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
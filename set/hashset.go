@@ -0,0 +1,309 @@
+package ks
+
+import (
+	"fmt"
+	"iter"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+	km "github.com/KeylimeVI/keylime-go/maps"
+	kp "github.com/KeylimeVI/keylime-go/pair"
+)
+
+// HashSet is a set whose elements need not be comparable, such as slices, kl.Lists or structs containing slices.
+// Elements are located with a hash function and told apart with an equal function;
+// elements that are equal must have the same hash. See the hashers in km, such as km.HashList.
+//
+// Operations combining two HashSets look elements up in each set with that set's own hash and equal functions,
+// and sets they return use the functions of the receiver. Combining sets that disagree on equality
+// can therefore give results that depend on the order of the operands.
+//
+// The zero value is an empty set that can be read, but it has no hash function:
+// create sets with NewHashSet, as adding to the zero value panics.
+type HashSet[T any] struct {
+	items *km.HashMap[T, struct{}]
+}
+
+// NewHashSet creates a new HashSet with the given items. Panics if hash or equal is nil.
+func NewHashSet[T any](hash func(T) uint64, equal func(a, b T) bool, items ...T) *HashSet[T] {
+	s := &HashSet[T]{items: km.NewHashMap[T, struct{}](hash, equal)}
+	return s.Add(items...)
+}
+
+// Add values to set
+func (s *HashSet[T]) Add(items ...T) *HashSet[T] {
+	if s.items == nil && len(items) > 0 {
+		panic("ks.HashSet.Add: the zero value has no hash function, use NewHashSet")
+	}
+	for _, item := range items {
+		s.items.Set(item, struct{}{})
+	}
+	return s
+}
+
+// IsEmpty Check if set is empty
+func (s *HashSet[T]) IsEmpty() bool {
+	return s.entries().IsEmpty()
+}
+
+// Remove items from set
+func (s *HashSet[T]) Remove(items ...T) *HashSet[T] {
+	for _, item := range items {
+		s.entries().Delete(item)
+	}
+	return s
+}
+
+// Pop removes and returns a random element from the set.
+// Returns an error if the set is empty.
+func (s *HashSet[T]) Pop() (T, error) {
+	for item := range s.entries().All() {
+		s.entries().Delete(item)
+		return item, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("pop: empty set")
+}
+
+// Contains checks if set contains all items
+func (s *HashSet[T]) Contains(items ...T) bool {
+	return s.entries().Has(items...)
+}
+
+// ContainsAny checks if set contains at least one item from items
+func (s *HashSet[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if s.entries().Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len Get size of set
+func (s *HashSet[T]) Len() int {
+	return s.entries().Len()
+}
+
+// Clear all items from set
+func (s *HashSet[T]) Clear() *HashSet[T] {
+	s.entries().Clear()
+	return s
+}
+
+// Copy the set, keeping its hash and equal functions
+func (s *HashSet[T]) Copy() *HashSet[T] {
+	if s.items == nil {
+		return &HashSet[T]{}
+	}
+	return &HashSet[T]{items: s.items.Copy()}
+}
+
+// String representation (for debugging)
+func (s *HashSet[T]) String() string {
+	return fmt.Sprintf("%v", s.ToSlice())
+}
+
+// Equals returns true if both sets contain exactly the same elements.
+func (s *HashSet[T]) Equals(other *HashSet[T]) bool {
+	return s.Len() == other.Len() && s.SubsetOf(other)
+}
+
+// SubsetOf Check if this set is a subset of another set
+func (s *HashSet[T]) SubsetOf(other *HashSet[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	return s.All(func(item T) bool { return other.Contains(item) })
+}
+
+// SupersetOf Check if this set is a superset of another set
+func (s *HashSet[T]) SupersetOf(other *HashSet[T]) bool {
+	return other.SubsetOf(s)
+}
+
+// ProperSubsetOf Check if this set is a subset of another set and not equal to it
+func (s *HashSet[T]) ProperSubsetOf(other *HashSet[T]) bool {
+	return s.Len() < other.Len() && s.SubsetOf(other)
+}
+
+// ProperSupersetOf Check if this set is a superset of another set and not equal to it
+func (s *HashSet[T]) ProperSupersetOf(other *HashSet[T]) bool {
+	return other.ProperSubsetOf(s)
+}
+
+// IsDisjoint returns true if the sets have no elements in common
+func (s *HashSet[T]) IsDisjoint(other *HashSet[T]) bool {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	return !small.Any(func(item T) bool { return large.Contains(item) })
+}
+
+// Union returns the union of multiple sets
+func (s *HashSet[T]) Union(others ...*HashSet[T]) *HashSet[T] {
+	return s.Copy().UnionWith(others...)
+}
+
+// UnionWith adds the elements of the other sets to the set in place. Supports method chaining.
+func (s *HashSet[T]) UnionWith(others ...*HashSet[T]) *HashSet[T] {
+	for _, other := range others {
+		for item := range other.Values() {
+			s.Add(item)
+		}
+	}
+	return s
+}
+
+// Intersection returns the intersection of multiple sets
+func (s *HashSet[T]) Intersection(others ...*HashSet[T]) *HashSet[T] {
+	return s.Copy().IntersectWith(others...)
+}
+
+// IntersectWith removes the elements missing from any of the other sets in place. Supports method chaining.
+func (s *HashSet[T]) IntersectWith(others ...*HashSet[T]) *HashSet[T] {
+	return s.Filter(func(item T) bool {
+		for _, other := range others {
+			if !other.Contains(item) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Difference returns the elements of s that are in none of the other sets (the relative complement, s minus others)
+func (s *HashSet[T]) Difference(others ...*HashSet[T]) *HashSet[T] {
+	return s.Copy().Subtract(others...)
+}
+
+// Subtract removes the elements of the other sets in place. Supports method chaining.
+func (s *HashSet[T]) Subtract(others ...*HashSet[T]) *HashSet[T] {
+	return s.Filter(func(item T) bool {
+		for _, other := range others {
+			if other.Contains(item) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// SymmetricDifference returns a set of elements that are in either s or other but not both.
+func (s *HashSet[T]) SymmetricDifference(other *HashSet[T]) *HashSet[T] {
+	result := s.Difference(other)
+	for item := range other.Values() {
+		if !s.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// Partition splits the set into the elements for which predicate returns true (A) and the rest (B)
+func (s *HashSet[T]) Partition(predicate func(T) bool) kp.Pair[*HashSet[T], *HashSet[T]] {
+	matching, rest := s.empty(), s.empty()
+	for item := range s.Values() {
+		if predicate(item) {
+			matching.Add(item)
+		} else {
+			rest.Add(item)
+		}
+	}
+	return kp.NewPair(matching, rest)
+}
+
+// Filter removes elements for which predicate returns false. Supports method chaining.
+func (s *HashSet[T]) Filter(predicate func(T) bool) *HashSet[T] {
+	for _, item := range s.ToSlice() {
+		if !predicate(item) {
+			s.entries().Delete(item)
+		}
+	}
+	return s
+}
+
+// Map replaces every element with the result of f, merging results that are equal. Supports method chaining.
+func (s *HashSet[T]) Map(f func(T) T) *HashSet[T] {
+	items := s.ToSlice()
+	s.Clear()
+	for _, item := range items {
+		s.Add(f(item))
+	}
+	return s
+}
+
+// FlatMap replaces every element with the elements f returns for it, merging equal ones. Supports method chaining.
+func (s *HashSet[T]) FlatMap(f func(T) []T) *HashSet[T] {
+	items := s.ToSlice()
+	s.Clear()
+	for _, item := range items {
+		s.Add(f(item)...)
+	}
+	return s
+}
+
+// ForEach calls f for every element, in no particular order. Supports method chaining.
+func (s *HashSet[T]) ForEach(f func(T)) *HashSet[T] {
+	for item := range s.Values() {
+		f(item)
+	}
+	return s
+}
+
+// Any returns true if predicate returns true for at least one element
+func (s *HashSet[T]) Any(predicate func(T) bool) bool {
+	for item := range s.Values() {
+		if predicate(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if predicate returns true for every element, or the set is empty
+func (s *HashSet[T]) All(predicate func(T) bool) bool {
+	for item := range s.Values() {
+		if !predicate(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Values returns an iterator over the elements in no particular order
+func (s *HashSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s.entries().All() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice converts the set to a slice
+func (s *HashSet[T]) ToSlice() []T {
+	return s.entries().Keys()
+}
+
+// ToList converts the set to a list
+func (s *HashSet[T]) ToList() kl.List[T] {
+	return s.entries().Keys()
+}
+
+// entries returns the underlying map, an empty one for the zero value
+func (s *HashSet[T]) entries() *km.HashMap[T, struct{}] {
+	if s.items == nil {
+		return &km.HashMap[T, struct{}]{}
+	}
+	return s.items
+}
+
+// empty returns a new empty set with the same hash and equal functions
+func (s *HashSet[T]) empty() *HashSet[T] {
+	if s.items == nil {
+		return &HashSet[T]{}
+	}
+	return NewHashSet(s.items.Hasher())
+}
@@ -0,0 +1,74 @@
+package ks
+
+import (
+	"strings"
+	"testing"
+
+	km "github.com/KeylimeVI/keylime-go/maps"
+)
+
+func hashFold(s string) uint64 { return km.HashString(strings.ToLower(s)) }
+
+func TestHashSetCombiningUsesReceiverForResults(t *testing.T) {
+	folded := NewHashSet(hashFold, strings.EqualFold, "a", "B")
+	exact := NewHashSet(km.HashString, km.Equal[string], "A", "b", "c")
+
+	// The union is built with the receiver's case-insensitive functions
+	if union := folded.Union(exact); union.Len() != 3 || !union.Contains("C", "a", "b") {
+		t.Errorf("folded.Union(exact) = %v, want 3 case-insensitive elements", union)
+	}
+	if union := exact.Union(folded); union.Len() != 5 {
+		t.Errorf("exact.Union(folded) = %v, want 5 case-sensitive elements", union)
+	}
+
+	// Lookups in the other set use its own functions: "a" is not in exact, but "A" is in folded
+	caseSensitive := NewHashSet(km.HashString, km.Equal[string], "A", "B")
+	if folded.SubsetOf(caseSensitive) {
+		t.Error(`{"a", "B"} is a subset of the case-sensitive {"A", "B"}`)
+	}
+	if !caseSensitive.SubsetOf(folded) {
+		t.Error(`the case-sensitive {"A", "B"} is not a subset of {"a", "B"}`)
+	}
+	if inter := exact.Intersection(folded); inter.Len() != 2 {
+		t.Errorf("exact.Intersection(folded) = %v, want A and b", inter)
+	}
+}
+
+func TestHashSetFunctional(t *testing.T) {
+	s := NewHashSet(km.HashString, km.Equal[string], "a", "bb", "ccc")
+	if !s.Any(func(v string) bool { return len(v) == 2 }) || s.All(func(v string) bool { return len(v) < 3 }) {
+		t.Error("Any or All gave the wrong answer")
+	}
+	s.Map(func(v string) string { return v[:1] }).Filter(func(v string) bool { return v != "c" })
+	if s.Len() != 2 || !s.Contains("a", "b") {
+		t.Errorf("after Map and Filter = %v, want [a b]", s)
+	}
+	s.FlatMap(func(v string) []string { return []string{v, "z"} })
+	if s.Len() != 3 || !s.Contains("a", "b", "z") {
+		t.Errorf("after FlatMap = %v, want [a b z]", s)
+	}
+}
+
+func TestHashSetZeroValue(t *testing.T) {
+	var s HashSet[string]
+	if !s.IsEmpty() || s.Len() != 0 || s.Contains("a") || s.ContainsAny("a") || len(s.ToSlice()) != 0 {
+		t.Error("the zero value reported an element")
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Error("Pop on the zero value returned no error")
+	}
+	s.Remove("a").Clear().Filter(func(string) bool { return false })
+	if parts := s.Partition(func(string) bool { return true }); !parts.A.IsEmpty() || !parts.B.IsEmpty() {
+		t.Error("Partition of the zero value is not empty")
+	}
+	other := NewHashSet(km.HashString, km.Equal[string], "a")
+	if !s.SubsetOf(other) || !s.IsDisjoint(other) || other.Union(s.Copy()).Len() != 1 {
+		t.Error("the zero value does not combine as an empty set")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Add on the zero value did not panic")
+		}
+	}()
+	s.Add("a")
+}
@@ -1,5 +1,14 @@
 package ks
 
+import "errors"
+
+// Exported sentinel errors
+var (
+	IncompatibleSketchError = errors.New("sketches have different parameters")
+	InvalidEncodingError    = errors.New("invalid binary encoding")
+	FilterFullError         = errors.New("filter is full")
+)
+
 // singleContains Check if set contains item
 func (s *Set[T]) singleContains(item T) bool {
 	_, exists := (*s)[item]
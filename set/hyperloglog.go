@@ -0,0 +1,156 @@
+package ks
+
+import (
+	"math"
+	"math/bits"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const (
+	hyperLogLogEncodingVersion = 1
+	MinHyperLogLogPrecision    = 4
+	MaxHyperLogLogPrecision    = 18
+)
+
+// HyperLogLog estimates the number of distinct items added to it using 2^precision bytes of memory.
+// The standard error of the estimate is about 1.04 / sqrt(2^precision).
+type HyperLogLog[T comparable] struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog estimator with the given precision.
+// Panics if precision is outside [MinHyperLogLogPrecision, MaxHyperLogLogPrecision].
+func NewHyperLogLog[T comparable](precision uint8) *HyperLogLog[T] {
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		panic("ks.NewHyperLogLog: precision out of range")
+	}
+	return &HyperLogLog[T]{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// HyperLogLogFromSet creates a HyperLogLog estimator seeded with the items of set
+func HyperLogLogFromSet[T comparable](set Set[T], precision uint8) *HyperLogLog[T] {
+	h := NewHyperLogLog[T](precision)
+	for item := range set {
+		h.Add(item)
+	}
+	return h
+}
+
+// HyperLogLogFromList creates a HyperLogLog estimator seeded with the items of list
+func HyperLogLogFromList[T comparable](list kl.List[T], precision uint8) *HyperLogLog[T] {
+	h := NewHyperLogLog[T](precision)
+	h.Add(list...)
+	return h
+}
+
+// Add items to the estimator
+//
+// Supports method chaining
+func (h *HyperLogLog[T]) Add(items ...T) *HyperLogLog[T] {
+	for _, item := range items {
+		x := hashOf(item)
+		index := x >> (64 - h.precision)
+		// The sentinel bit caps the rank when the remaining bits are all zero
+		w := x<<h.precision | 1<<(h.precision-1)
+		rank := uint8(bits.LeadingZeros64(w) + 1)
+		if rank > h.registers[index] {
+			h.registers[index] = rank
+		}
+	}
+	return h
+}
+
+// Count returns the estimated number of distinct items added
+func (h *HyperLogLog[T]) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+	// Linear counting is more accurate while many registers are still empty
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Precision returns the precision the estimator was created with
+func (h *HyperLogLog[T]) Precision() uint8 {
+	return h.precision
+}
+
+// Clear all items from the estimator
+func (h *HyperLogLog[T]) Clear() *HyperLogLog[T] {
+	clear(h.registers)
+	return h
+}
+
+// Copy returns a new independent copy of the estimator
+func (h *HyperLogLog[T]) Copy() *HyperLogLog[T] {
+	registers := make([]uint8, len(h.registers))
+	copy(registers, h.registers)
+	return &HyperLogLog[T]{precision: h.precision, registers: registers}
+}
+
+// Merge adds all items of other into the estimator. Both estimators must have the same precision.
+//
+// Errors: IncompatibleSketchError
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	if h.precision != other.precision {
+		return IncompatibleSketchError
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 3+len(h.registers))
+	data = append(data, 'H', hyperLogLogEncodingVersion, h.precision)
+	data = append(data, h.registers...)
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != 'H' || data[1] != hyperLogLogEncodingVersion {
+		return InvalidEncodingError
+	}
+	precision := data[2]
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision || len(data)-3 != 1<<precision {
+		return InvalidEncodingError
+	}
+	registers := make([]uint8, 1<<precision)
+	copy(registers, data[3:])
+	*h = HyperLogLog[T]{precision: precision, registers: registers}
+	return nil
+}
+
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
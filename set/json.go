@@ -0,0 +1,42 @@
+package ks
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+)
+
+// MarshalJSON encodes the set as a JSON array.
+// The elements are sorted when T is an ordered type (integers, floats, strings) and by their encoding otherwise,
+// so the same set always produces the same output.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := s.ToSlice()
+	if kcodec.SortOrdered(items) {
+		return json.Marshal(items)
+	}
+
+	encoded := make([]json.RawMessage, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = data
+	}
+	slices.SortFunc(encoded, func(a, b json.RawMessage) int {
+		return bytes.Compare(a, b)
+	})
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its contents. Duplicate elements are collapsed.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*s = NewSetCap(len(items), items...)
+	return nil
+}
@@ -0,0 +1,52 @@
+package ks
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSetJSON(t *testing.T) {
+	type point struct{ X, Y int }
+	for _, c := range []struct {
+		name string
+		set  any
+		want string
+	}{
+		{"ints", NewSet(3, -1, 20, 0), `[-1,0,3,20]`},
+		{"strings", NewSet("pear", "apple", "fig"), `["apple","fig","pear"]`},
+		{"floats", NewSet(2.5, -0.5, 1.0), `[-0.5,1,2.5]`},
+		{"structs", NewSet(point{2, 1}, point{1, 9}, point{1, 2}), `[{"X":1,"Y":2},{"X":1,"Y":9},{"X":2,"Y":1}]`},
+		{"empty", NewSet[int](), `[]`},
+	} {
+		// Marshal repeatedly, as map iteration order changes between runs
+		for i := 0; i < 5; i++ {
+			data, err := json.Marshal(c.set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != c.want {
+				t.Fatalf("%s: Marshal = %s, want %s", c.name, data, c.want)
+			}
+		}
+	}
+
+	var s Set[string]
+	if err := json.Unmarshal([]byte(`["b", "a", "b"]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Contains("a", "b") {
+		t.Fatalf("Unmarshal = %v, want duplicates collapsed to {a b}", s)
+	}
+	if err := json.Unmarshal([]byte(`["c"]`), &s); err != nil || s.Len() != 1 || !s.Contains("c") {
+		t.Fatalf("Unmarshal into a set = %v, %v, want its contents replaced", s, err)
+	}
+	if err := json.Unmarshal([]byte(`{"a": true}`), &s); err == nil {
+		t.Error("Unmarshal of an object succeeded")
+	}
+
+	wrapped := struct{ Tags Set[int] }{NewSet(2, 1)}
+	data, _ := json.Marshal(wrapped)
+	if string(data) != `{"Tags":[1,2]}` {
+		t.Errorf("Marshal of a struct field = %s", data)
+	}
+}
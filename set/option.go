@@ -0,0 +1,19 @@
+package ks
+
+import ko "github.com/KeylimeVI/keylime-go/option"
+
+// PopOpt is Pop returning an Option: removes and returns a random element, or None if the set is empty
+func (s *Set[T]) PopOpt() ko.Option[T] {
+	item, err := s.Pop()
+	return ko.OptionOf(item, err == nil)
+}
+
+// FindOpt returns an element for which predicate returns true, or None
+func (s *Set[T]) FindOpt(predicate func(T) bool) ko.Option[T] {
+	for item := range *s {
+		if predicate(item) {
+			return ko.Some(item)
+		}
+	}
+	return ko.None[T]()
+}
@@ -0,0 +1,13 @@
+package ks
+
+import "testing"
+
+func TestSetOptions(t *testing.T) {
+	s := NewSet(4)
+	if s.FindOpt(func(n int) bool { return n > 3 }).Unwrap() != 4 || s.FindOpt(func(n int) bool { return n > 4 }).IsSome() {
+		t.Error("FindOpt returned the wrong option")
+	}
+	if s.PopOpt().Unwrap() != 4 || s.PopOpt().IsSome() || !s.IsEmpty() {
+		t.Error("PopOpt did not remove the only element and then return None")
+	}
+}
@@ -1,7 +1,15 @@
+// Package ks contains a map-backed Set, a HashSet for non-comparable elements, and the probabilistic
+// BloomFilter, CuckooFilter, HyperLogLog, CountMinSketch and TopK sketches.
+//
+// The sketches hash scalar elements from their bytes and others from their %#v representation, which prints
+// memory addresses for pointers and for structs, arrays or interfaces holding them. Their MarshalBinary
+// encodings can only be read or merged by another process for element types without pointers.
 package ks
 
 import (
 	"fmt"
+
+	kp "github.com/KeylimeVI/keylime-go/pair"
 )
 
 // Set is a generic set of comparable elements implemented as map[T]struct{}.
@@ -111,9 +119,9 @@ func (s *Set[T]) Copy() Set[T] {
 	return result
 }
 
-// String representation (for debugging)
+// String representation in sorted order (for debugging)
 func (s *Set[T]) String() string {
-	return fmt.Sprintf("%v", s.ToSlice())
+	return fmt.Sprintf("%v", *s)
 }
 
 // Equals returns true if both sets contain exactly the same elements.
@@ -145,28 +153,75 @@ func (s *Set[T]) SupersetOf(other Set[T]) bool {
 
 // Union returns the union of multiple sets
 func (s *Set[T]) Union(others ...Set[T]) Set[T] {
-	result := s.Copy()
-	flattened := Flatten(others)
-	result.Add(flattened.ToList()...)
+	size := len(*s)
+	for _, other := range others {
+		size += len(other)
+	}
+	result := NewSetCap[T](size)
+	result.UnionWith(*s)
+	result.UnionWith(others...)
 	return result
 }
 
+// UnionWith adds the elements of the other sets to the set in place. Supports method chaining.
+func (s *Set[T]) UnionWith(others ...Set[T]) *Set[T] {
+	for _, other := range others {
+		for item := range other {
+			(*s)[item] = struct{}{}
+		}
+	}
+	return s
+}
+
 // Intersection returns the intersection of multiple sets
 func (s *Set[T]) Intersection(others ...Set[T]) Set[T] {
 	result := s.Copy()
-	result.Filter(func(item T) bool {
+	result.IntersectWith(others...)
+	return result
+}
+
+// IntersectWith removes the elements missing from any of the other sets in place. Supports method chaining.
+func (s *Set[T]) IntersectWith(others ...Set[T]) *Set[T] {
+	return s.Filter(func(item T) bool {
 		for _, other := range others {
-			if !other.Contains(item) {
+			if !other.singleContains(item) {
 				return false
 			}
 		}
 		return true
 	})
+}
+
+// Difference returns the elements of s that are in none of the other sets (the relative complement, s minus others).
+//
+// Migration: Difference used to return the symmetric difference of two sets.
+// Callers relying on that behavior should call SymmetricDifference instead.
+func (s *Set[T]) Difference(others ...Set[T]) Set[T] {
+	result := s.Copy()
+	result.Subtract(others...)
 	return result
 }
 
-// Difference returns a set of elements that are in either s or other but not both.
-func (s *Set[T]) Difference(other Set[T]) Set[T] {
+// Subtract removes the elements of the other sets in place. Supports method chaining.
+func (s *Set[T]) Subtract(others ...Set[T]) *Set[T] {
+	for _, other := range others {
+		if len(other) < len(*s) {
+			for item := range other {
+				delete(*s, item)
+			}
+			continue
+		}
+		for item := range *s {
+			if other.singleContains(item) {
+				delete(*s, item)
+			}
+		}
+	}
+	return s
+}
+
+// SymmetricDifference returns a set of elements that are in either s or other but not both.
+func (s *Set[T]) SymmetricDifference(other Set[T]) Set[T] {
 	result := NewSet[T]()
 	for item := range *s {
 		if !other.singleContains(item) {
@@ -181,6 +236,43 @@ func (s *Set[T]) Difference(other Set[T]) Set[T] {
 	return result
 }
 
+// IsDisjoint returns true if the sets have no elements in common
+func (s *Set[T]) IsDisjoint(other Set[T]) bool {
+	small, large := *s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for item := range small {
+		if large.singleContains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProperSubsetOf Check if this set is a subset of another set and not equal to it
+func (s *Set[T]) ProperSubsetOf(other Set[T]) bool {
+	return len(*s) < len(other) && s.SubsetOf(other)
+}
+
+// ProperSupersetOf Check if this set is a superset of another set and not equal to it
+func (s *Set[T]) ProperSupersetOf(other Set[T]) bool {
+	return other.ProperSubsetOf(*s)
+}
+
+// Partition splits the set into the elements for which predicate returns true (A) and the rest (B)
+func (s *Set[T]) Partition(predicate func(T) bool) kp.Pair[Set[T], Set[T]] {
+	matching, rest := NewSet[T](), NewSet[T]()
+	for item := range *s {
+		if predicate(item) {
+			matching.Add(item)
+		} else {
+			rest.Add(item)
+		}
+	}
+	return kp.NewPair(matching, rest)
+}
+
 // Filter removes elements for which predicate returns false. Supports method chaining.
 func (s *Set[T]) Filter(predicate func(T) bool) *Set[T] {
 	for item := range *s {
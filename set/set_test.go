@@ -0,0 +1,125 @@
+package ks
+
+import (
+	"testing"
+
+	kp "github.com/KeylimeVI/keylime-go/pair"
+)
+
+func TestDifference(t *testing.T) {
+	a := NewSet(1, 2, 3, 4)
+	for _, c := range []struct {
+		name   string
+		others []Set[int]
+		want   Set[int]
+	}{
+		{"no others", nil, NewSet(1, 2, 3, 4)},
+		{"one other", []Set[int]{NewSet(2, 5)}, NewSet(1, 3, 4)},
+		{"several others", []Set[int]{NewSet(1), NewSet(4, 9)}, NewSet(2, 3)},
+		{"larger other", []Set[int]{NewSet(0, 1, 2, 3, 5, 6, 7)}, NewSet(4)},
+		{"everything", []Set[int]{NewSet(1, 2, 3, 4)}, NewSet[int]()},
+	} {
+		got := a.Difference(c.others...)
+		if !got.Equals(c.want) {
+			t.Errorf("%s: Difference = %v, want %v", c.name, got, c.want)
+		}
+	}
+	if !a.Equals(NewSet(1, 2, 3, 4)) {
+		t.Fatalf("Difference modified the receiver: %v", a)
+	}
+
+	// Difference is the relative complement, no longer the symmetric difference
+	b := NewSet(3, 4, 5)
+	if got := a.Difference(b); !got.Equals(NewSet(1, 2)) {
+		t.Errorf("Difference = %v, want [1 2]", got)
+	}
+	if got := a.SymmetricDifference(b); !got.Equals(NewSet(1, 2, 5)) {
+		t.Errorf("SymmetricDifference = %v, want [1 2 5]", got)
+	}
+}
+
+func TestInPlaceOperations(t *testing.T) {
+	s := NewSet(1, 2, 3, 4, 5)
+	s.Subtract(NewSet(1), NewSet(2, 3, 4, 5, 6, 7, 8)).UnionWith(NewSet(7), NewSet(8, 9))
+	if want := NewSet(7, 8, 9); !s.Equals(want) {
+		t.Fatalf("Subtract then UnionWith = %v, want %v", s, want)
+	}
+	s.IntersectWith(NewSet(7, 8, 10), NewSet(8, 9, 7))
+	if want := NewSet(7, 8); !s.Equals(want) {
+		t.Fatalf("IntersectWith = %v, want %v", s, want)
+	}
+	s.IntersectWith()
+	if want := NewSet(7, 8); !s.Equals(want) {
+		t.Fatalf("IntersectWith() = %v, want %v unchanged", s, want)
+	}
+	s.IntersectWith(NewSet[int]())
+	if len(s) != 0 {
+		t.Fatalf("IntersectWith(empty) = %v, want empty", s)
+	}
+
+	u := NewSet(1)
+	if got := u.Union(NewSet(2), NewSet(3)); !got.Equals(NewSet(1, 2, 3)) || len(u) != 1 {
+		t.Fatalf("Union = %v with receiver %v", got, u)
+	}
+	if got := u.Intersection(NewSet(1, 2)); !got.Equals(NewSet(1)) {
+		t.Fatalf("Intersection = %v, want [1]", got)
+	}
+}
+
+func TestSetRelations(t *testing.T) {
+	small, large, other := NewSet(1, 2), NewSet(1, 2, 3), NewSet(4)
+	empty := NewSet[int]()
+	for _, c := range []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"small IsDisjoint other", small.IsDisjoint(other), true},
+		{"large IsDisjoint small", large.IsDisjoint(small), false},
+		{"empty IsDisjoint empty", empty.IsDisjoint(empty), true},
+		{"small ProperSubsetOf large", small.ProperSubsetOf(large), true},
+		{"large ProperSubsetOf large", large.ProperSubsetOf(large), false},
+		{"small ProperSubsetOf other", small.ProperSubsetOf(NewSet(1, 4, 5)), false},
+		{"empty ProperSubsetOf small", empty.ProperSubsetOf(small), true},
+		{"empty ProperSubsetOf empty", empty.ProperSubsetOf(empty), false},
+		{"large ProperSupersetOf small", large.ProperSupersetOf(small), true},
+		{"small SubsetOf small", small.SubsetOf(small), true},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestSetCartesianProduct(t *testing.T) {
+	got := CartesianProduct(NewSet(1, 2), NewSet("a", "b", "c"))
+	if len(got) != 6 {
+		t.Fatalf("CartesianProduct has %d pairs, want 6", len(got))
+	}
+	for _, x := range []int{1, 2} {
+		for _, y := range []string{"a", "b", "c"} {
+			if !got.Contains(kp.NewPair(x, y)) {
+				t.Errorf("CartesianProduct is missing (%d, %s)", x, y)
+			}
+		}
+	}
+	if got := CartesianProduct(NewSet(1), NewSet[string]()); len(got) != 0 {
+		t.Errorf("CartesianProduct with an empty set = %v, want empty", got)
+	}
+}
+
+func TestSetPartition(t *testing.T) {
+	s := NewSet(1, 2, 3, 4, 5)
+	parts := s.Partition(func(v int) bool { return v%2 == 0 })
+	if !parts.A.Equals(NewSet(2, 4)) || !parts.B.Equals(NewSet(1, 3, 5)) {
+		t.Fatalf("Partition = %v, %v, want [2 4], [1 3 5]", parts.A, parts.B)
+	}
+	if len(s) != 5 {
+		t.Fatalf("Partition modified the receiver: %v", s)
+	}
+	empty := NewSet[int]()
+	parts = empty.Partition(func(int) bool { return true })
+	if parts.A == nil || parts.B == nil || len(parts.A)+len(parts.B) != 0 {
+		t.Fatalf("Partition of empty set = %v, %v, want two empty sets", parts.A, parts.B)
+	}
+}
@@ -0,0 +1,321 @@
+package ks
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// sketchHeader builds a Bloom or Count-Min style header of three little-endian words
+func sketchHeader(magic byte, version byte, words ...uint64) []byte {
+	data := []byte{magic, version}
+	for _, w := range words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data
+}
+
+func TestBloomFilterRejectsBadHeaders(t *testing.T) {
+	for name, data := range map[string][]byte{
+		// (m+63)/64*8 wraps to 0, matching the empty body
+		"wrapping m":  sketchHeader('B', bloomEncodingVersion, 1<<64-1, 3, 0),
+		"m past data": append(sketchHeader('B', bloomEncodingVersion, 1<<20, 3, 0), make([]byte, 8)...),
+		"huge k":      append(sketchHeader('B', bloomEncodingVersion, 64, 1<<62, 0), make([]byte, 8)...),
+	} {
+		var b BloomFilter[int]
+		if err := b.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("%s: UnmarshalBinary = %v, want InvalidEncodingError", name, err)
+		}
+	}
+}
+
+func TestCuckooFilterRejectsBadHeaders(t *testing.T) {
+	// n*cuckooBucketSize*2 wraps to 0, matching the empty body
+	data := sketchHeader('C', cuckooEncodingVersion, 1<<62, 0, 0)
+	data = append(data, 0, 0)
+	var c CuckooFilter[int]
+	if err := c.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+		t.Errorf("UnmarshalBinary = %v, want InvalidEncodingError", err)
+	}
+}
+
+func TestCountMinSketchRejectsBadHeaders(t *testing.T) {
+	for name, data := range map[string][]byte{
+		// width*depth*8 wraps to 0, matching the empty body
+		"wrapping size":   sketchHeader('M', countMinEncodingVersion, 1<<32, 1<<29, 0),
+		"width past data": append(sketchHeader('M', countMinEncodingVersion, 1<<20, 1, 0), make([]byte, 8)...),
+	} {
+		var c CountMinSketch[int]
+		if err := c.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("%s: UnmarshalBinary = %v, want InvalidEncodingError", name, err)
+		}
+	}
+}
+
+func TestSketchRoundTrip(t *testing.T) {
+	bloom := NewBloomFilter[string](100, 0.01).Add("a", "b")
+	cuckoo := NewCuckooFilter[string](100)
+	if err := cuckoo.Add("a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	hll := NewHyperLogLog[string](10).Add("a", "b")
+
+	var bloomCopy BloomFilter[string]
+	var cuckooCopy CuckooFilter[string]
+	var hllCopy HyperLogLog[string]
+	for _, c := range []struct {
+		from interface{ MarshalBinary() ([]byte, error) }
+		to   interface{ UnmarshalBinary([]byte) error }
+	}{{bloom, &bloomCopy}, {cuckoo, &cuckooCopy}, {hll, &hllCopy}} {
+		data, err := c.from.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.to.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bloomCopy.Contains("a", "b") || !cuckooCopy.Contains("a", "b") || hllCopy.Count() != hll.Count() {
+		t.Fatal("decoded sketches lost their items")
+	}
+}
+
+func FuzzSketchBinary(f *testing.F) {
+	for _, m := range []interface{ MarshalBinary() ([]byte, error) }{
+		NewBloomFilter[int](10, 0.1).Add(1, 2),
+		NewCuckooFilter[int](10),
+		NewHyperLogLog[int](4).Add(1, 2),
+		NewCountMinSketch[int](0.1, 0.1).Add(1, 2),
+	} {
+		data, _ := m.MarshalBinary()
+		f.Add(data)
+	}
+
+	// Whatever decodes must be usable without panicking
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var b BloomFilter[int]
+		if b.UnmarshalBinary(data) == nil {
+			b.Add(1, 2, 3)
+			_ = b.Contains(4)
+		}
+		var c CuckooFilter[int]
+		if c.UnmarshalBinary(data) == nil {
+			_ = c.Add(1, 2, 3)
+			_ = c.Contains(4)
+			_ = c.Remove(1)
+		}
+		var h HyperLogLog[int]
+		if h.UnmarshalBinary(data) == nil {
+			h.Add(1, 2, 3)
+			_ = h.Count()
+		}
+		var m CountMinSketch[int]
+		if m.UnmarshalBinary(data) == nil {
+			m.Add(1, 2, 3)
+			_ = m.Estimate(4)
+		}
+	})
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	for _, rate := range []float64{0.1, 0.01, 0.001} {
+		const n, queries = 10_000, 200_000
+		b := NewBloomFilter[int](n, rate)
+		for i := range n {
+			b.Add(i)
+		}
+		for i := range n {
+			if !b.Contains(i) {
+				t.Fatalf("rate %v: false negative for %d", rate, i)
+			}
+		}
+		positives := 0
+		for i := n; i < n+queries; i++ {
+			if b.Contains(i) {
+				positives++
+			}
+		}
+		// Allow for variance and rounding of the bit and hash counts
+		if measured := float64(positives) / queries; measured > rate*1.5 {
+			t.Errorf("rate %v: measured false positive rate %v", rate, measured)
+		}
+		if estimate := b.FalsePositiveRate(); estimate > rate*1.5 || estimate < rate/1.5 {
+			t.Errorf("rate %v: FalsePositiveRate estimate %v", rate, estimate)
+		}
+	}
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	a := NewBloomFilter[string](100, 0.01).Add("a", "b")
+	b := NewBloomFilter[string](100, 0.01).Add("c")
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Contains("a", "b", "c") || a.Len() != 3 {
+		t.Fatalf("merged filter lost items, Len = %d", a.Len())
+	}
+	if b.Contains("a") {
+		t.Fatal("Merge modified its argument")
+	}
+	if err := a.Merge(NewBloomFilter[string](1000, 0.01)); !errors.Is(err, IncompatibleSketchError) {
+		t.Fatalf("Merge of a differently sized filter = %v, want IncompatibleSketchError", err)
+	}
+}
+
+func TestCuckooFilterFalsePositiveRate(t *testing.T) {
+	const n, queries = 10_000, 500_000
+	c := NewCuckooFilter[int](n)
+	for i := range n {
+		if err := c.Add(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	positives := 0
+	for i := n; i < n+queries; i++ {
+		if c.Contains(i) {
+			positives++
+		}
+	}
+	// Two buckets of four 16-bit fingerprints give at most 8/65535, about 0.012%
+	if measured := float64(positives) / queries; measured > 0.0003 {
+		t.Errorf("measured false positive rate %v, want about 0.0001", measured)
+	}
+}
+
+func TestCuckooFilterRemove(t *testing.T) {
+	c := NewCuckooFilter[string](100)
+	if err := c.Add("a", "b", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Remove("a") || c.Contains("a") {
+		t.Fatal("Remove(a) did not remove it")
+	}
+	// An item added twice needs two removals
+	if !c.Remove("b") || !c.Contains("b") || !c.Remove("b") || c.Contains("b") {
+		t.Fatal("duplicate item was not removed once per Remove")
+	}
+	if c.Remove("never added") || c.Len() != 0 {
+		t.Fatalf("Remove of an absent item returned true or Len = %d", c.Len())
+	}
+}
+
+func TestCuckooFilterMerge(t *testing.T) {
+	a := NewCuckooFilter[int](100)
+	b := NewCuckooFilter[int](100)
+	_ = a.Add(1, 2)
+	_ = b.Add(3, 4)
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Contains(1, 2, 3, 4) || a.Len() != 4 {
+		t.Fatalf("merged filter lost items, Len = %d", a.Len())
+	}
+	if !a.Remove(3) || a.Contains(3) {
+		t.Fatal("an item merged in could not be removed")
+	}
+	if err := a.Merge(NewCuckooFilter[int](10_000)); !errors.Is(err, IncompatibleSketchError) {
+		t.Fatalf("Merge of a differently sized filter = %v, want IncompatibleSketchError", err)
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	c := NewCuckooFilter[string](8)
+	var added []string
+	for i := 0; ; i++ {
+		item := fmt.Sprint("item", i)
+		if err := c.Add(item); err != nil {
+			if !errors.Is(err, FilterFullError) {
+				t.Fatalf("Add = %v, want FilterFullError", err)
+			}
+			break
+		}
+		added = append(added, item)
+		if i > c.Cap() {
+			t.Fatalf("filter with %d slots accepted %d items", c.Cap(), i)
+		}
+	}
+	if !c.IsFull() || c.Len() != len(added) {
+		t.Fatalf("IsFull = %v, Len = %d, want true, %d", c.IsFull(), c.Len(), len(added))
+	}
+	// The item that filled the filter is held as the victim, so nothing added is lost
+	for _, item := range added {
+		if !c.Contains(item) {
+			t.Fatalf("full filter lost %q", item)
+		}
+	}
+
+	// Removing an item makes room for the victim again
+	if !c.Remove(added[0]) {
+		t.Fatal("Remove from a full filter failed")
+	}
+	for _, item := range added[1:] {
+		if !c.Contains(item) {
+			t.Fatalf("filter lost %q after Remove", item)
+		}
+	}
+	if c.Len() != len(added)-1 {
+		t.Fatalf("Len after Remove = %d, want %d", c.Len(), len(added)-1)
+	}
+	if c.Clear(); c.IsFull() || c.Len() != 0 || c.Add("x") != nil {
+		t.Fatal("Clear did not empty the filter")
+	}
+}
+
+// zipfStream returns a skewed stream of n items drawn from 1000 distinct values and their true counts
+func zipfStream(seed uint64, n int) ([]uint64, map[uint64]uint64) {
+	z := rand.NewZipf(rand.New(rand.NewPCG(seed, seed)), 1.2, 1, 999)
+	stream := make([]uint64, n)
+	counts := map[uint64]uint64{}
+	for i := range stream {
+		stream[i] = z.Uint64()
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+func TestCountMinSketchNeverUndercounts(t *testing.T) {
+	const epsilon = 0.001
+	stream, counts := zipfStream(1, 100_000)
+	c := NewCountMinSketch[uint64](epsilon, 0.01).Add(stream...)
+	if c.Total() != uint64(len(stream)) {
+		t.Fatalf("Total = %d, want %d", c.Total(), len(stream))
+	}
+	over := 0
+	for item := range uint64(1000) {
+		estimate := c.Estimate(item)
+		if estimate < counts[item] {
+			t.Fatalf("Estimate(%d) = %d, below the true count %d", item, estimate, counts[item])
+		}
+		if float64(estimate-counts[item]) > epsilon*float64(c.Total()) {
+			over++
+		}
+	}
+	// Each estimate may exceed the bound with probability delta
+	if over > 30 {
+		t.Errorf("%d of 1000 estimates overcount by more than epsilon * Total", over)
+	}
+}
+
+func TestCountMinSketchMergeEqualsUnion(t *testing.T) {
+	first, _ := zipfStream(2, 20_000)
+	second, _ := zipfStream(3, 30_000)
+	a := NewCountMinSketch[uint64](0.01, 0.01).Add(first...)
+	b := NewCountMinSketch[uint64](0.01, 0.01).Add(second...)
+	union := NewCountMinSketch[uint64](0.01, 0.01).Add(first...).Add(second...)
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Total() != union.Total() {
+		t.Fatalf("merged Total = %d, want %d", a.Total(), union.Total())
+	}
+	for item := range uint64(1000) {
+		if a.Estimate(item) != union.Estimate(item) {
+			t.Fatalf("merged Estimate(%d) = %d, want %d", item, a.Estimate(item), union.Estimate(item))
+		}
+	}
+	if err := a.Merge(NewCountMinSketch[uint64](0.1, 0.01)); !errors.Is(err, IncompatibleSketchError) {
+		t.Fatalf("Merge of a differently sized sketch = %v, want IncompatibleSketchError", err)
+	}
+}
@@ -0,0 +1,40 @@
+package ks
+
+import (
+	"database/sql/driver"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// PGArray converts the set to a kl.PGArray, sorted when T is ordered, so it is stored as a PostgreSQL array literal
+func (s Set[T]) PGArray() kl.PGArray[T] {
+	items := s.ToSlice()
+	kcodec.SortOrdered(items)
+	return items
+}
+
+// Value implements driver.Valuer, storing the set as a JSON array in the order of MarshalJSON. A nil set is stored as NULL.
+func (s Set[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner, reading either a JSON array or a PostgreSQL array literal, see kl.List.Scan.
+// Duplicate elements are collapsed and NULL scans to an empty set.
+//
+// Errors: kl.InvalidEncodingError
+func (s *Set[T]) Scan(src any) error {
+	var items kl.List[T]
+	if err := items.Scan(src); err != nil {
+		return err
+	}
+	*s = NewSetCap(len(items), items...)
+	return nil
+}
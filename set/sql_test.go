@@ -0,0 +1,163 @@
+package ks
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// echoDriver is an in-memory driver whose every query returns one row holding its arguments,
+// so a value goes through Value on the way in and Scan on the way out like it would with a real database
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("transactions are not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *echoRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func init() {
+	sql.Register("ks-echo", echoDriver{})
+}
+
+func openEcho(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("ks-echo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// echo stores value and scans it back into dest
+func echo(t *testing.T, db *sql.DB, value any, dest any) {
+	t.Helper()
+	if err := db.QueryRow("echo", value).Scan(dest); err != nil {
+		t.Fatalf("echo %v: %v", value, err)
+	}
+}
+
+func TestSQLJSON(t *testing.T) {
+	db := openEcho(t)
+
+	var stored string
+	echo(t, db, NewSet(3, 1, 2), &stored)
+	if stored != "[1,2,3]" {
+		t.Fatalf("Value = %q, want the sorted [1,2,3]", stored)
+	}
+
+	items := NewSet("a", `quote " and \ backslash`, "NULL", "{}", "")
+	var got Set[string]
+	echo(t, db, items, &got)
+	if !got.Equals(items) {
+		t.Fatalf("round trip = %v, want %v", got, items)
+	}
+
+	var dedup Set[int]
+	echo(t, db, "[1,2,2,1]", &dedup)
+	if !dedup.Equals(NewSet(1, 2)) {
+		t.Fatalf("Scan = %v, want duplicates collapsed", dedup)
+	}
+}
+
+func TestSQLPGArray(t *testing.T) {
+	db := openEcho(t)
+
+	var stored string
+	echo(t, db, NewSet("b", "NULL", `x"y`, `a\b`, "{}", "").PGArray(), &stored)
+	if want := `{"","NULL","a\\b",b,"x\"y","{}"}`; stored != want {
+		t.Fatalf("Value = %s, want %s", stored, want)
+	}
+
+	var got Set[string]
+	echo(t, db, stored, &got)
+	if !got.Equals(NewSet("b", "NULL", `x"y`, `a\b`, "{}", "")) {
+		t.Fatalf("Scan = %v", got)
+	}
+
+	var pointers Set[*int]
+	echo(t, db, "{NULL,NULL}", &pointers)
+	if pointers.Len() != 1 || !pointers.Contains(nil) {
+		t.Fatalf("Scan = %v, want a single nil element", pointers)
+	}
+}
+
+// A NULL column scans to an empty set, but only a nil set is stored as NULL,
+// so an empty set is written back as an empty array
+func TestSQLNullAndEmpty(t *testing.T) {
+	db := openEcho(t)
+
+	var null any = "unset"
+	echo(t, db, Set[int](nil), &null)
+	if null != nil {
+		t.Fatalf("nil set stored as %v, want NULL", null)
+	}
+
+	var stored string
+	echo(t, db, NewSet[int](), &stored)
+	if stored != "[]" {
+		t.Fatalf("empty set stored as %q, want []", stored)
+	}
+
+	var scanned Set[int]
+	echo(t, db, nil, &scanned)
+	if scanned == nil || scanned.Len() != 0 {
+		t.Fatalf("NULL scanned to %#v, want an empty set", scanned)
+	}
+	echo(t, db, scanned, &stored)
+	if stored != "[]" {
+		t.Fatalf("set scanned from NULL stored as %q, want []", stored)
+	}
+
+	for _, src := range []string{"[]", "{}"} {
+		var empty Set[int]
+		echo(t, db, src, &empty)
+		if empty == nil || empty.Len() != 0 {
+			t.Fatalf("%s scanned to %#v, want an empty set", src, empty)
+		}
+	}
+}
+
+func TestSQLScanErrors(t *testing.T) {
+	db := openEcho(t)
+	for _, src := range []any{"nope", "[1,", "{1,{2}}", int64(1)} {
+		var s Set[int]
+		if err := db.QueryRow("echo", src).Scan(&s); !errors.Is(err, kl.InvalidEncodingError) {
+			t.Errorf("Scan(%v) = %v, want kl.InvalidEncodingError", src, err)
+		}
+	}
+}
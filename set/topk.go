@@ -0,0 +1,187 @@
+package ks
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+	kp "github.com/KeylimeVI/keylime-go/pair"
+)
+
+// TopK tracks the approximately most frequent items of a stream with the Space-Saving algorithm.
+// It keeps k counters; an item's estimated count overcounts by at most its Error.
+type TopK[T comparable] struct {
+	k       int
+	entries map[T]*topKEntry[T]
+	heap    topKHeap[T]
+}
+
+type topKEntry[T comparable] struct {
+	item  T
+	count uint64
+	error uint64
+	index int
+}
+
+// NewTopK creates a tracker for the k most frequent items. Panics if k < 1.
+func NewTopK[T comparable](k int) *TopK[T] {
+	if k < 1 {
+		panic("ks.NewTopK: k must be at least 1")
+	}
+	return &TopK[T]{
+		k:       k,
+		entries: make(map[T]*topKEntry[T], k),
+		heap:    make(topKHeap[T], 0, k),
+	}
+}
+
+// TopKFromList creates a tracker seeded with the items of list
+func TopKFromList[T comparable](list kl.List[T], k int) *TopK[T] {
+	t := NewTopK[T](k)
+	t.Add(list...)
+	return t
+}
+
+// Add counts one occurrence of each item
+//
+// Supports method chaining
+func (t *TopK[T]) Add(items ...T) *TopK[T] {
+	for _, item := range items {
+		t.AddCount(item, 1)
+	}
+	return t
+}
+
+// AddCount counts count occurrences of item
+//
+// Supports method chaining
+func (t *TopK[T]) AddCount(item T, count uint64) *TopK[T] {
+	if entry, ok := t.entries[item]; ok {
+		entry.count += count
+		heap.Fix(&t.heap, entry.index)
+		return t
+	}
+	if len(t.heap) < t.k {
+		entry := &topKEntry[T]{item: item, count: count}
+		t.entries[item] = entry
+		heap.Push(&t.heap, entry)
+		return t
+	}
+	// Evict the least frequent item, the newcomer inherits its count as error bound
+	entry := t.heap[0]
+	delete(t.entries, entry.item)
+	entry.item = item
+	entry.error = entry.count
+	entry.count += count
+	t.entries[item] = entry
+	heap.Fix(&t.heap, 0)
+	return t
+}
+
+// Items returns the tracked items and their estimated counts, most frequent first
+func (t *TopK[T]) Items() kl.List[kp.Pair[T, uint64]] {
+	entries := slices.Clone([]*topKEntry[T](t.heap))
+	slices.SortFunc(entries, func(a, b *topKEntry[T]) int {
+		return cmp.Compare(b.count, a.count)
+	})
+	return kl.Map[*topKEntry[T], kp.Pair[T, uint64], []*topKEntry[T], kl.List[kp.Pair[T, uint64]]](entries, func(entry *topKEntry[T]) kp.Pair[T, uint64] {
+		return kp.NewPair(entry.item, entry.count)
+	})
+}
+
+// Estimate returns the estimated count of item and true, or (0, false) if the item is not tracked
+func (t *TopK[T]) Estimate(item T) (uint64, bool) {
+	entry, ok := t.entries[item]
+	if !ok {
+		return 0, false
+	}
+	return entry.count, true
+}
+
+// Error returns the maximum amount by which the estimated count of item may overcount, or (0, false) if the item is not tracked
+func (t *TopK[T]) Error(item T) (uint64, bool) {
+	entry, ok := t.entries[item]
+	if !ok {
+		return 0, false
+	}
+	return entry.error, true
+}
+
+// K returns the number of items the tracker keeps
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Len returns the number of items currently tracked
+func (t *TopK[T]) Len() int {
+	return len(t.heap)
+}
+
+// Merge combines the counts of other into the tracker, keeping the tracker's k.
+// Items missing from a full tracker are assumed to have that tracker's minimum count, as in mergeable Space-Saving summaries.
+func (t *TopK[T]) Merge(other *TopK[T]) {
+	floor, otherFloor := t.floor(), other.floor()
+
+	merged := make(map[T]*topKEntry[T], len(t.entries)+len(other.entries))
+	for item, entry := range t.entries {
+		merged[item] = &topKEntry[T]{item: item, count: entry.count + otherFloor, error: entry.error + otherFloor}
+	}
+	for item, entry := range other.entries {
+		if m, ok := merged[item]; ok {
+			m.count = m.count - otherFloor + entry.count
+			m.error = m.error - otherFloor + entry.error
+		} else {
+			merged[item] = &topKEntry[T]{item: item, count: entry.count + floor, error: entry.error + floor}
+		}
+	}
+
+	entries := make([]*topKEntry[T], 0, len(merged))
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b *topKEntry[T]) int {
+		return cmp.Compare(b.count, a.count)
+	})
+	if len(entries) > t.k {
+		entries = entries[:t.k]
+	}
+
+	t.entries = make(map[T]*topKEntry[T], t.k)
+	t.heap = make(topKHeap[T], 0, t.k)
+	for _, entry := range entries {
+		t.entries[entry.item] = entry
+		heap.Push(&t.heap, entry)
+	}
+}
+
+// floor returns the count assumed for items this tracker may have evicted
+func (t *TopK[T]) floor() uint64 {
+	if len(t.heap) < t.k {
+		return 0
+	}
+	return t.heap[0].count
+}
+
+type topKHeap[T comparable] []*topKEntry[T]
+
+func (h topKHeap[T]) Len() int           { return len(h) }
+func (h topKHeap[T]) Less(i, j int) bool { return h[i].count < h[j].count }
+func (h topKHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap[T]) Push(x any) {
+	entry := x.(*topKEntry[T])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *topKHeap[T]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,71 @@
+package ks
+
+import "testing"
+
+// checkSpaceSaving verifies the Space-Saving guarantees of topK against the true counts of a stream of n items
+func checkSpaceSaving(t *testing.T, topK *TopK[uint64], counts map[uint64]uint64, n uint64) {
+	t.Helper()
+	items := topK.Items()
+	if len(items) != topK.K() {
+		t.Fatalf("Items has %d entries, want %d", len(items), topK.K())
+	}
+	bound := n / uint64(topK.K())
+	for i, pair := range items {
+		item, estimate := pair.A, pair.B
+		if i > 0 && estimate > items[i-1].B {
+			t.Fatalf("Items is not most frequent first: %d after %d", estimate, items[i-1].B)
+		}
+		errorBound, _ := topK.Error(item)
+		if estimate < counts[item] || estimate-errorBound > counts[item] {
+			t.Fatalf("item %d: estimate %d with error %d does not bound the true count %d", item, estimate, errorBound, counts[item])
+		}
+		if errorBound > bound {
+			t.Fatalf("item %d: error %d exceeds n/k = %d", item, errorBound, bound)
+		}
+	}
+	// Every item occurring more than n/k times must be tracked
+	for item, count := range counts {
+		if _, ok := topK.Estimate(item); count > bound && !ok {
+			t.Fatalf("item %d occurs %d > n/k = %d times but is not tracked", item, count, bound)
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	stream, counts := zipfStream(4, 100_000)
+	topK := NewTopK[uint64](20).Add(stream...)
+	checkSpaceSaving(t, topK, counts, uint64(len(stream)))
+
+	// The skew makes the heaviest items certain
+	if first := topK.Items()[0]; first.A != 0 {
+		t.Fatalf("most frequent item = %d, want 0", first.A)
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	first, firstCounts := zipfStream(5, 40_000)
+	second, secondCounts := zipfStream(6, 60_000)
+	counts := map[uint64]uint64{}
+	for item, count := range firstCounts {
+		counts[item] += count
+	}
+	for item, count := range secondCounts {
+		counts[item] += count
+	}
+
+	merged := NewTopK[uint64](20).Add(first...)
+	merged.Merge(NewTopK[uint64](20).Add(second...))
+	checkSpaceSaving(t, merged, counts, uint64(len(first)+len(second)))
+
+	// Merging trackers that are not full is exact
+	small := NewTopK[string](5).Add("a", "b", "a")
+	small.Merge(NewTopK[string](5).Add("a", "c"))
+	for item, want := range map[string]uint64{"a": 3, "b": 1, "c": 1} {
+		if got, ok := small.Estimate(item); !ok || got != want {
+			t.Errorf("Estimate(%q) = %d, %v, want %d", item, got, ok, want)
+		}
+		if e, _ := small.Error(item); e != 0 {
+			t.Errorf("Error(%q) = %d, want 0", item, e)
+		}
+	}
+}
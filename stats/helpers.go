@@ -0,0 +1,15 @@
+package kstat
+
+import "errors"
+
+// Exported sentinel errors
+var (
+	LengthMismatchError   = errors.New("lists have different lengths")
+	InsufficientDataError = errors.New("not enough values")
+	QuantileRangeError    = errors.New("quantile out of range")
+	ZeroWeightError       = errors.New("weights sum to zero")
+	ZeroVarianceError     = errors.New("variance is zero")
+	BinCountError         = errors.New("bin count must be positive")
+	NonFiniteError        = errors.New("values must be finite")
+	InvalidEncodingError  = errors.New("invalid binary encoding")
+)
@@ -0,0 +1,284 @@
+package kstat
+
+import (
+	"encoding/binary"
+	"math"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const (
+	summaryEncodingVersion = 1
+	emaEncodingVersion     = 1
+)
+
+// Summary accumulates the count, mean, variance, minimum and maximum of a stream of values
+// in constant memory.
+//
+// A Summary is not safe for concurrent use: give each goroutine its own and combine them with Merge.
+type Summary[T kl.RealNumber] struct {
+	count uint64
+	mean  float64
+	m2    float64
+	min   T
+	max   T
+}
+
+// NewSummary creates a new Summary with the specified values
+func NewSummary[T kl.RealNumber](values ...T) *Summary[T] {
+	s := &Summary[T]{}
+	s.Add(values...)
+	return s
+}
+
+// Add values to the summary
+//
+// Supports method chaining
+func (s *Summary[T]) Add(values ...T) *Summary[T] {
+	for _, value := range values {
+		if s.count == 0 || value < s.min {
+			s.min = value
+		}
+		if s.count == 0 || value > s.max {
+			s.max = value
+		}
+		s.count++
+		x := float64(value)
+		delta := x - s.mean
+		s.mean += delta / float64(s.count)
+		s.m2 += delta * (x - s.mean)
+	}
+	return s
+}
+
+// Merge combines the values summarized by other into the summary
+//
+// Supports method chaining
+func (s *Summary[T]) Merge(other *Summary[T]) *Summary[T] {
+	if other.count == 0 {
+		return s
+	}
+	if s.count == 0 {
+		*s = *other
+		return s
+	}
+	// Chan et al. parallel update of mean and squared deviations
+	n := float64(s.count + other.count)
+	delta := other.mean - s.mean
+	s.m2 += other.m2 + delta*delta*float64(s.count)*float64(other.count)/n
+	s.mean += delta * float64(other.count) / n
+	s.count += other.count
+	s.min = min(s.min, other.min)
+	s.max = max(s.max, other.max)
+	return s
+}
+
+// Count returns the number of values added
+func (s *Summary[T]) Count() uint64 {
+	return s.count
+}
+
+// Mean returns the mean of the values, or 0 if none were added
+func (s *Summary[T]) Mean() float64 {
+	return s.mean
+}
+
+// Sum returns the sum of the values
+func (s *Summary[T]) Sum() float64 {
+	return s.mean * float64(s.count)
+}
+
+// Variance returns the population variance of the values, or 0 if none were added
+func (s *Summary[T]) Variance() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.count)
+}
+
+// SampleVariance returns the unbiased sample variance of the values, or 0 if fewer than two were added
+func (s *Summary[T]) SampleVariance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// StdDev returns the population standard deviation of the values
+func (s *Summary[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of the values
+func (s *Summary[T]) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Min returns the smallest value, or false if none were added
+func (s *Summary[T]) Min() (T, bool) {
+	return s.min, s.count > 0
+}
+
+// Max returns the largest value, or false if none were added
+func (s *Summary[T]) Max() (T, bool) {
+	return s.max, s.count > 0
+}
+
+// Clear resets the summary
+func (s *Summary[T]) Clear() *Summary[T] {
+	*s = Summary[T]{}
+	return s
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *Summary[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+5*8)
+	data = append(data, 'S', summaryEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, s.count)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.mean))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.m2))
+	data = binary.LittleEndian.AppendUint64(data, numberBits(s.min))
+	data = binary.LittleEndian.AppendUint64(data, numberBits(s.max))
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (s *Summary[T]) UnmarshalBinary(data []byte) error {
+	if len(data) != 2+5*8 || data[0] != 'S' || data[1] != summaryEncodingVersion {
+		return InvalidEncodingError
+	}
+	data = data[2:]
+	*s = Summary[T]{
+		count: binary.LittleEndian.Uint64(data),
+		mean:  math.Float64frombits(binary.LittleEndian.Uint64(data[8:])),
+		m2:    math.Float64frombits(binary.LittleEndian.Uint64(data[16:])),
+		min:   numberFromBits[T](binary.LittleEndian.Uint64(data[24:])),
+		max:   numberFromBits[T](binary.LittleEndian.Uint64(data[32:])),
+	}
+	return nil
+}
+
+// EMA is an exponential moving average, weighting each new value by alpha and the previous average by 1 - alpha.
+//
+// Unlike the other accumulators an EMA depends on the order of its values, so it cannot be merged.
+type EMA[T kl.RealNumber] struct {
+	alpha float64
+	value float64
+	count uint64
+}
+
+// NewEMA creates an exponential moving average with smoothing factor alpha. Panics if alpha is not in (0, 1].
+func NewEMA[T kl.RealNumber](alpha float64) *EMA[T] {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("kstat.NewEMA: alpha must be in (0, 1]")
+	}
+	return &EMA[T]{alpha: alpha}
+}
+
+// NewEMAWindow creates an exponential moving average whose smoothing matches a simple moving average over window values.
+// Panics if window < 1.
+func NewEMAWindow[T kl.RealNumber](window int) *EMA[T] {
+	if window < 1 {
+		panic("kstat.NewEMAWindow: window must be at least 1")
+	}
+	return NewEMA[T](2 / (float64(window) + 1))
+}
+
+// Add values to the average in order. The first value initializes the average.
+//
+// Supports method chaining
+func (e *EMA[T]) Add(values ...T) *EMA[T] {
+	for _, value := range values {
+		if e.count == 0 {
+			e.value = float64(value)
+		} else {
+			e.value += e.alpha * (float64(value) - e.value)
+		}
+		e.count++
+	}
+	return e
+}
+
+// Value returns the current average, or 0 if no values were added
+func (e *EMA[T]) Value() float64 {
+	return e.value
+}
+
+// Count returns the number of values added
+func (e *EMA[T]) Count() uint64 {
+	return e.count
+}
+
+// Alpha returns the smoothing factor
+func (e *EMA[T]) Alpha() float64 {
+	return e.alpha
+}
+
+// Clear resets the average, keeping the smoothing factor
+func (e *EMA[T]) Clear() *EMA[T] {
+	e.value = 0
+	e.count = 0
+	return e
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (e *EMA[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+3*8)
+	data = append(data, 'E', emaEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(e.alpha))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(e.value))
+	data = binary.LittleEndian.AppendUint64(data, e.count)
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (e *EMA[T]) UnmarshalBinary(data []byte) error {
+	if len(data) != 2+3*8 || data[0] != 'E' || data[1] != emaEncodingVersion {
+		return InvalidEncodingError
+	}
+	alpha := math.Float64frombits(binary.LittleEndian.Uint64(data[2:]))
+	if !(alpha > 0 && alpha <= 1) {
+		return InvalidEncodingError
+	}
+	*e = EMA[T]{
+		alpha: alpha,
+		value: math.Float64frombits(binary.LittleEndian.Uint64(data[10:])),
+		count: binary.LittleEndian.Uint64(data[18:]),
+	}
+	return nil
+}
+
+// numberBits encodes any RealNumber in 64 bits without losing precision
+func numberBits[T kl.RealNumber](v T) uint64 {
+	if isFloat[T]() {
+		return math.Float64bits(float64(v))
+	}
+	if isSigned[T]() {
+		return uint64(int64(v))
+	}
+	return uint64(v)
+}
+
+func numberFromBits[T kl.RealNumber](bits uint64) T {
+	if isFloat[T]() {
+		return T(math.Float64frombits(bits))
+	}
+	if isSigned[T]() {
+		return T(int64(bits))
+	}
+	return T(bits)
+}
+
+func isFloat[T kl.RealNumber]() bool {
+	var one T = 1
+	return one/2 != 0
+}
+
+func isSigned[T kl.RealNumber]() bool {
+	var zero T
+	return zero-1 < 0
+}
@@ -0,0 +1,103 @@
+package kstat
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSummaryMergeMatchesBatch(t *testing.T) {
+	values := []float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	wantVariance, _ := Variance(values)
+	wantSample, _ := SampleVariance(values)
+	wantMean, _ := Mean(values)
+
+	for split := range len(values) + 1 {
+		s := NewSummary(values[:split]...).Merge(NewSummary(values[split:]...))
+		if s.Count() != uint64(len(values)) {
+			t.Fatalf("split %d: Count = %d, want %d", split, s.Count(), len(values))
+		}
+		if !nearlyEqual(s.Mean(), wantMean) || !nearlyEqual(s.Variance(), wantVariance) || !nearlyEqual(s.SampleVariance(), wantSample) {
+			t.Fatalf("split %d: mean %v variance %v sample %v, want %v %v %v",
+				split, s.Mean(), s.Variance(), s.SampleVariance(), wantMean, wantVariance, wantSample)
+		}
+		if lo, _ := s.Min(); lo != 1 {
+			t.Fatalf("split %d: Min = %v, want 1", split, lo)
+		}
+		if hi, _ := s.Max(); hi != 9 {
+			t.Fatalf("split %d: Max = %v, want 9", split, hi)
+		}
+	}
+}
+
+func TestSummaryEmpty(t *testing.T) {
+	var s Summary[int]
+	if _, ok := s.Min(); ok {
+		t.Fatal("Min of empty summary reported a value")
+	}
+	if s.Variance() != 0 || s.SampleVariance() != 0 || s.Mean() != 0 {
+		t.Fatal("empty summary has non-zero moments")
+	}
+	if NewSummary(5).SampleVariance() != 0 {
+		t.Fatal("SampleVariance of one value is not 0")
+	}
+}
+
+func TestSummaryRoundTrip(t *testing.T) {
+	s := NewSummary[int64](-3, 7, 1<<60)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Summary[int64]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != *s {
+		t.Fatalf("decoded = %+v, want %+v", decoded, *s)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	e := NewEMA[int](0.5).Add(10)
+	if e.Value() != 10 {
+		t.Fatalf("Value after first Add = %v, want 10", e.Value())
+	}
+	e.Add(20, 0)
+	// 10 -> 15 -> 7.5
+	if e.Value() != 7.5 || e.Count() != 3 {
+		t.Fatalf("Value, Count = %v, %d, want 7.5, 3", e.Value(), e.Count())
+	}
+	if got := NewEMAWindow[int](3).Alpha(); got != 0.5 {
+		t.Fatalf("NewEMAWindow(3).Alpha = %v, want 0.5", got)
+	}
+	if e.Clear().Value() != 0 || e.Count() != 0 || e.Alpha() != 0.5 {
+		t.Fatal("Clear did not reset the average or lost alpha")
+	}
+}
+
+func TestEMARoundTrip(t *testing.T) {
+	e := NewEMA[float64](0.25).Add(1, 2, 3)
+	data, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded EMA[float64]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != *e {
+		t.Fatalf("decoded = %+v, want %+v", decoded, *e)
+	}
+
+	for _, alpha := range []float64{0, 1.5, math.NaN()} {
+		bad, _ := (&EMA[float64]{alpha: alpha}).MarshalBinary()
+		if err := decoded.UnmarshalBinary(bad); !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("alpha %v: UnmarshalBinary = %v, want InvalidEncodingError", alpha, err)
+		}
+	}
+}
+
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(b))
+}
@@ -0,0 +1,265 @@
+// Package kstat contains descriptive statistics over slices and Lists of real numbers
+package kstat
+
+import (
+	"math"
+	"slices"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+	kp "github.com/KeylimeVI/keylime-go/pair"
+)
+
+// Bucket is a histogram bin covering values from Low (inclusive) to High (exclusive).
+// The last bucket of a histogram also includes High.
+type Bucket struct {
+	Low  float64
+	High float64
+}
+
+// Mean returns the arithmetic mean of the list
+//
+// Errors: EmptyListError
+func Mean[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	if len(list) == 0 {
+		return 0, kl.EmptyListError
+	}
+	mean := 0.0
+	for i, item := range list {
+		mean += (float64(item) - mean) / float64(i+1)
+	}
+	return mean, nil
+}
+
+// WeightedMean returns the mean of values weighted by the corresponding weights
+//
+// Errors: EmptyListError, LengthMismatchError, ZeroWeightError
+func WeightedMean[T kl.RealNumber, W kl.RealNumber, S ~[]T, SW ~[]W](values S, weights SW) (float64, error) {
+	if len(values) == 0 {
+		return 0, kl.EmptyListError
+	}
+	if len(values) != len(weights) {
+		return 0, LengthMismatchError
+	}
+	sum, totalWeight := 0.0, 0.0
+	for i, value := range values {
+		sum += float64(value) * float64(weights[i])
+		totalWeight += float64(weights[i])
+	}
+	if totalWeight == 0 {
+		return 0, ZeroWeightError
+	}
+	return sum / totalWeight, nil
+}
+
+// Median returns the middle value of the list, or the mean of the two middle values if its length is even.
+// The list is not modified.
+//
+// Errors: EmptyListError
+func Median[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	return Quantile(list, 0.5)
+}
+
+// Quantile returns the q-quantile of the list for q in [0, 1], interpolating linearly between
+// closest ranks. Runs in average O(n) time using selection rather than sorting. The list is not modified.
+//
+// Errors: EmptyListError, QuantileRangeError
+func Quantile[T kl.RealNumber, S ~[]T](list S, q float64) (float64, error) {
+	if len(list) == 0 {
+		return 0, kl.EmptyListError
+	}
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, QuantileRangeError
+	}
+	work := slices.Clone([]T(list))
+	position := q * float64(len(work)-1)
+	k := int(position)
+	lower, _ := kl.Select(work, k)
+	if k == len(work)-1 || position == float64(k) {
+		return float64(lower), nil
+	}
+	// After selection everything past k is at least lower, so the next rank is the smallest of them
+	upper := slices.Min(work[k+1:])
+	return float64(lower) + (position-float64(k))*(float64(upper)-float64(lower)), nil
+}
+
+// Percentile returns the p-th percentile of the list for p in [0, 100], see Quantile
+//
+// Errors: EmptyListError, QuantileRangeError
+func Percentile[T kl.RealNumber, S ~[]T](list S, p float64) (float64, error) {
+	return Quantile(list, p/100)
+}
+
+// Mode returns the most frequent values of the list in ascending order
+//
+// Errors: EmptyListError
+func Mode[T kl.RealNumber, S ~[]T](list S) (kl.List[T], error) {
+	if len(list) == 0 {
+		return nil, kl.EmptyListError
+	}
+	counts := make(map[T]int, len(list))
+	highest := 0
+	for _, item := range list {
+		counts[item]++
+		highest = max(highest, counts[item])
+	}
+	modes := kl.NewList[T]()
+	for item, count := range counts {
+		if count == highest {
+			modes.Add(item)
+		}
+	}
+	kl.Sort(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance of the list
+//
+// Errors: EmptyListError
+func Variance[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	if len(list) == 0 {
+		return 0, kl.EmptyListError
+	}
+	_, m2 := welford(list)
+	return m2 / float64(len(list)), nil
+}
+
+// SampleVariance returns the unbiased sample variance of the list
+//
+// Errors: InsufficientDataError
+func SampleVariance[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	if len(list) < 2 {
+		return 0, InsufficientDataError
+	}
+	_, m2 := welford(list)
+	return m2 / float64(len(list)-1), nil
+}
+
+// StdDev returns the population standard deviation of the list
+//
+// Errors: EmptyListError
+func StdDev[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	variance, err := Variance(list)
+	return math.Sqrt(variance), err
+}
+
+// SampleStdDev returns the sample standard deviation of the list
+//
+// Errors: InsufficientDataError
+func SampleStdDev[T kl.RealNumber, S ~[]T](list S) (float64, error) {
+	variance, err := SampleVariance(list)
+	return math.Sqrt(variance), err
+}
+
+// Covariance returns the population covariance of two lists of the same length
+//
+// Errors: EmptyListError, LengthMismatchError
+func Covariance[T kl.RealNumber, U kl.RealNumber, S ~[]T, SU ~[]U](x S, y SU) (float64, error) {
+	if len(x) != len(y) {
+		return 0, LengthMismatchError
+	}
+	if len(x) == 0 {
+		return 0, kl.EmptyListError
+	}
+	comoment, _, _ := coMoments(x, y)
+	return comoment / float64(len(x)), nil
+}
+
+// SampleCovariance returns the unbiased sample covariance of two lists of the same length
+//
+// Errors: InsufficientDataError, LengthMismatchError
+func SampleCovariance[T kl.RealNumber, U kl.RealNumber, S ~[]T, SU ~[]U](x S, y SU) (float64, error) {
+	if len(x) != len(y) {
+		return 0, LengthMismatchError
+	}
+	if len(x) < 2 {
+		return 0, InsufficientDataError
+	}
+	comoment, _, _ := coMoments(x, y)
+	return comoment / float64(len(x)-1), nil
+}
+
+// Correlation returns the Pearson correlation coefficient of two lists of the same length
+//
+// Errors: InsufficientDataError, LengthMismatchError, ZeroVarianceError
+func Correlation[T kl.RealNumber, U kl.RealNumber, S ~[]T, SU ~[]U](x S, y SU) (float64, error) {
+	if len(x) != len(y) {
+		return 0, LengthMismatchError
+	}
+	if len(x) < 2 {
+		return 0, InsufficientDataError
+	}
+	comoment, m2x, m2y := coMoments(x, y)
+	if m2x == 0 || m2y == 0 {
+		return 0, ZeroVarianceError
+	}
+	return comoment / math.Sqrt(m2x*m2y), nil
+}
+
+// Histogram counts the values of the list in bins equal-width buckets spanning its minimum to its maximum.
+// The values must be finite, since no equal-width buckets can span an infinite range.
+//
+// Errors: EmptyListError, BinCountError, NonFiniteError
+func Histogram[T kl.RealNumber, S ~[]T](list S, bins int) (kl.List[kp.Pair[Bucket, int]], error) {
+	if len(list) == 0 {
+		return nil, kl.EmptyListError
+	}
+	if bins < 1 {
+		return nil, BinCountError
+	}
+	// slices.Min and slices.Max propagate NaN, so this also rejects NaN values
+	low, high := float64(slices.Min(list)), float64(slices.Max(list))
+	if math.IsNaN(low) || math.IsNaN(high) || math.IsInf(low, 0) || math.IsInf(high, 0) {
+		return nil, NonFiniteError
+	}
+	width := (high - low) / float64(bins)
+
+	counts := make([]int, bins)
+	for _, item := range list {
+		bin := bins - 1
+		// A NaN position, from equal values or a range too wide for float64, falls in the last bin
+		if position := (float64(item) - low) / width; position < float64(bins-1) {
+			bin = int(position)
+		}
+		counts[bin]++
+	}
+
+	result := kl.NewListCap[kp.Pair[Bucket, int]](bins)
+	for i, count := range counts {
+		bucket := Bucket{Low: low + float64(i)*width, High: low + float64(i+1)*width}
+		if i == bins-1 {
+			bucket.High = high
+		}
+		result.Add(kp.NewPair(bucket, count))
+	}
+	return result, nil
+}
+
+// welford returns the mean and the sum of squared deviations from it in a single numerically stable pass
+func welford[T kl.RealNumber, S ~[]T](list S) (float64, float64) {
+	mean, m2 := 0.0, 0.0
+	for i, item := range list {
+		x := float64(item)
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return mean, m2
+}
+
+// coMoments returns the co-moment of x and y and the sums of squared deviations of each in a single stable pass
+func coMoments[T kl.RealNumber, U kl.RealNumber, S ~[]T, SU ~[]U](x S, y SU) (float64, float64, float64) {
+	meanX, meanY := 0.0, 0.0
+	comoment, m2x, m2y := 0.0, 0.0, 0.0
+	for i := range x {
+		n := float64(i + 1)
+		xi, yi := float64(x[i]), float64(y[i])
+		deltaX := xi - meanX
+		deltaY := yi - meanY
+		meanX += deltaX / n
+		meanY += deltaY / n
+		comoment += deltaX * (yi - meanY)
+		m2x += deltaX * (xi - meanX)
+		m2y += deltaY * (yi - meanY)
+	}
+	return comoment, m2x, m2y
+}
@@ -0,0 +1,236 @@
+package kstat
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+func TestMean(t *testing.T) {
+	for _, c := range []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{5}, 5},
+		{[]float64{1, 2, 3, 4}, 2.5},
+		{[]float64{-1, 1}, 0},
+		{[]float64{1e308, 1e308}, 1e308},
+	} {
+		if got, err := Mean(c.values); err != nil || got != c.want {
+			t.Errorf("Mean(%v) = %v, %v, want %v", c.values, got, err, c.want)
+		}
+	}
+}
+
+func TestMode(t *testing.T) {
+	for _, c := range []struct {
+		values []int
+		want   []int
+	}{
+		{[]int{7}, []int{7}},
+		{[]int{1, 2, 2, 3}, []int{2}},
+		{[]int{3, 1, 3, 1, 2}, []int{1, 3}},
+		{[]int{4, 3, 2}, []int{2, 3, 4}},
+	} {
+		if got, err := Mode(c.values); err != nil || !slices.Equal(got, c.want) {
+			t.Errorf("Mode(%v) = %v, %v, want %v", c.values, got, err, c.want)
+		}
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	for _, c := range []struct {
+		values  []float64
+		weights []int
+		want    float64
+	}{
+		{[]float64{1, 2, 3}, []int{1, 1, 1}, 2},
+		{[]float64{1, 10}, []int{3, 1}, 3.25},
+		{[]float64{1, 10}, []int{0, 2}, 10},
+	} {
+		if got, err := WeightedMean(c.values, c.weights); err != nil || got != c.want {
+			t.Errorf("WeightedMean(%v, %v) = %v, %v, want %v", c.values, c.weights, got, err, c.want)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	values := []int{40, 10, 30, 20, 50}
+	for _, c := range []struct {
+		q    float64
+		want float64
+	}{
+		{0, 10},
+		{0.25, 20},
+		{0.3, 22},
+		{0.5, 30},
+		{0.9, 46},
+		{1, 50},
+	} {
+		if got, err := Quantile(values, c.q); err != nil || math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("Quantile(%v) = %v, %v, want %v", c.q, got, err, c.want)
+		}
+	}
+	if !slices.Equal(values, []int{40, 10, 30, 20, 50}) {
+		t.Errorf("Quantile modified its input: %v", values)
+	}
+	if got, _ := Median([]int{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("Median of even length = %v, want 2.5", got)
+	}
+	if got, _ := Percentile([]int{1, 2, 3}, 50); got != 2 {
+		t.Errorf("Percentile(50) = %v, want 2", got)
+	}
+	if got, _ := Quantile([]int{7}, 0.5); got != 7 {
+		t.Errorf("Quantile of one value = %v, want 7", got)
+	}
+}
+
+func TestVarianceMatchesSampleVariance(t *testing.T) {
+	for _, values := range [][]float64{
+		{1, 2},
+		{2, 4, 4, 4, 5, 5, 7, 9},
+		{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+	} {
+		n := float64(len(values))
+		variance, _ := Variance(values)
+		sample, _ := SampleVariance(values)
+		if !nearlyEqual(sample, variance*n/(n-1)) {
+			t.Errorf("%v: SampleVariance = %v, want Variance*n/(n-1) = %v", values, sample, variance*n/(n-1))
+		}
+		stdDev, _ := StdDev(values)
+		sampleStdDev, _ := SampleStdDev(values)
+		if !nearlyEqual(stdDev*stdDev, variance) || !nearlyEqual(sampleStdDev*sampleStdDev, sample) {
+			t.Errorf("%v: standard deviations do not square to the variances", values)
+		}
+	}
+	if got, _ := Variance([]float64{2, 4, 4, 4, 5, 5, 7, 9}); got != 4 {
+		t.Errorf("Variance = %v, want 4", got)
+	}
+	// The shifted values must not lose their variance to cancellation
+	if got, _ := Variance([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}); got != 22.5 {
+		t.Errorf("Variance of shifted values = %v, want 22.5", got)
+	}
+}
+
+func TestCovarianceAndCorrelation(t *testing.T) {
+	for _, c := range []struct {
+		x, y        []float64
+		covariance  float64
+		correlation float64
+	}{
+		{[]float64{1, 2, 3}, []float64{2, 4, 6}, 4.0 / 3, 1},
+		{[]float64{1, 2, 3}, []float64{3, 2, 1}, -2.0 / 3, -1},
+		{[]float64{1, 2, 3, 4}, []float64{1, 3, 2, 4}, 1, 0.8},
+	} {
+		covariance, err := Covariance(c.x, c.y)
+		if err != nil || !nearlyEqual(covariance, c.covariance) {
+			t.Errorf("Covariance(%v, %v) = %v, %v, want %v", c.x, c.y, covariance, err, c.covariance)
+		}
+		n := float64(len(c.x))
+		if sample, _ := SampleCovariance(c.x, c.y); !nearlyEqual(sample, c.covariance*n/(n-1)) {
+			t.Errorf("SampleCovariance(%v, %v) = %v, want %v", c.x, c.y, sample, c.covariance*n/(n-1))
+		}
+		correlation, err := Correlation(c.x, c.y)
+		if err != nil || !nearlyEqual(correlation, c.correlation) {
+			t.Errorf("Correlation(%v, %v) = %v, %v, want %v", c.x, c.y, correlation, err, c.correlation)
+		}
+	}
+	if got, _ := Covariance([]float64{1, 2, 3}, []float64{1, 2, 3}); !nearlyEqual(got, 2.0/3) {
+		t.Errorf("Covariance of a list with itself = %v, want its variance 2/3", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	got, err := Histogram([]float64{0, 1, 2, 2.5, 5, 9.99, 10}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		low, high float64
+		count     int
+	}{{0, 2.5, 3}, {2.5, 5, 1}, {5, 7.5, 1}, {7.5, 10, 2}}
+	if len(got) != len(want) {
+		t.Fatalf("Histogram has %d buckets, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if b := got[i].A; b.Low != w.low || b.High != w.high || got[i].B != w.count {
+			t.Errorf("bucket %d = %v %d, want [%v, %v) %d", i, b, got[i].B, w.low, w.high, w.count)
+		}
+	}
+
+	// Equal values share the last bucket
+	got, _ = Histogram([]int{3, 3, 3}, 2)
+	if got[0].B != 0 || got[1].B != 3 {
+		t.Errorf("Histogram of equal values = %v, want all in the last bucket", got)
+	}
+
+	// A range too wide for float64 must still fill every value into some bucket
+	got, _ = Histogram([]float64{-math.MaxFloat64, 0, math.MaxFloat64}, 3)
+	total := 0
+	for _, bucket := range got {
+		total += bucket.B
+	}
+	if total != 3 {
+		t.Errorf("Histogram of an overflowing range counted %d values, want 3", total)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	for name, err := range map[string]error{
+		"Mean":                      second(Mean([]float64{})),
+		"WeightedMean":              second(WeightedMean([]float64{}, []float64{})),
+		"Median":                    second(Median([]float64{})),
+		"Quantile":                  second(Quantile([]float64{}, 0.5)),
+		"Variance":                  second(Variance([]float64{})),
+		"StdDev":                    second(StdDev([]float64{})),
+		"Covariance":                second(Covariance([]float64{}, []float64{})),
+		"Histogram":                 second(Histogram([]float64{}, 3)),
+		"Mode":                      second(Mode([]float64{})),
+		"SampleVariance":            second(SampleVariance([]float64{1})),
+		"SampleStdDev":              second(SampleStdDev([]float64{1})),
+		"SampleCovariance":          second(SampleCovariance([]float64{1}, []float64{1})),
+		"Correlation":               second(Correlation([]float64{1}, []float64{1})),
+		"WeightedMean mismatch":     second(WeightedMean([]float64{1}, []float64{1, 2})),
+		"Covariance mismatch":       second(Covariance([]float64{1}, []float64{1, 2})),
+		"Correlation mismatch":      second(Correlation([]float64{1, 2}, []float64{1})),
+		"WeightedMean zero weights": second(WeightedMean([]float64{1, 2}, []float64{1, -1})),
+		"Correlation zero variance": second(Correlation([]float64{1, 1}, []float64{1, 2})),
+		"Quantile below 0":          second(Quantile([]float64{1}, -0.1)),
+		"Quantile above 1":          second(Quantile([]float64{1}, 1.1)),
+		"Quantile NaN":              second(Quantile([]float64{1}, nan)),
+		"Percentile above 100":      second(Percentile([]float64{1}, 101)),
+		"Histogram no bins":         second(Histogram([]float64{1}, 0)),
+		"Histogram +Inf":            second(Histogram([]float64{0, 1, inf}, 3)),
+		"Histogram -Inf":            second(Histogram([]float64{-inf, 0, 1}, 3)),
+		"Histogram NaN":             second(Histogram([]float64{0, nan, 1}, 3)),
+	} {
+		if err == nil {
+			t.Errorf("%s returned nil error", name)
+		}
+	}
+
+	for _, c := range []struct {
+		err, want error
+	}{
+		{second(Mean([]int{})), kl.EmptyListError},
+		{second(SampleVariance([]int{1})), InsufficientDataError},
+		{second(Covariance([]int{1}, []int{})), LengthMismatchError},
+		{second(WeightedMean([]int{1}, []int{0})), ZeroWeightError},
+		{second(Correlation([]int{1, 1}, []int{1, 2})), ZeroVarianceError},
+		{second(Quantile([]int{1}, 2)), QuantileRangeError},
+		{second(Histogram([]int{1}, -1)), BinCountError},
+		{second(Histogram([]float64{0, 1, inf}, 3)), NonFiniteError},
+	} {
+		if !errors.Is(c.err, c.want) {
+			t.Errorf("error = %v, want %v", c.err, c.want)
+		}
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
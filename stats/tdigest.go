@@ -0,0 +1,239 @@
+package kstat
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"slices"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+const (
+	tDigestEncodingVersion = 1
+	// DefaultCompression keeps quantile estimates within about 1% in the middle and much closer at the tails
+	DefaultCompression = 100
+)
+
+// TDigest estimates quantiles of a stream of values in memory bounded by its compression (Dunning's merging t-digest).
+// Larger compression keeps more centroids and gives more accurate estimates.
+//
+// The zero value is an empty digest with DefaultCompression.
+// A TDigest is not safe for concurrent use: give each goroutine its own and combine them with Merge.
+type TDigest[T kl.RealNumber] struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest creates a t-digest with the given compression, see DefaultCompression. Panics if compression < 1 or NaN.
+func NewTDigest[T kl.RealNumber](compression float64) *TDigest[T] {
+	if !(compression >= 1) || math.IsInf(compression, 1) {
+		panic("kstat.NewTDigest: compression must be at least 1")
+	}
+	return &TDigest[T]{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add values to the digest
+//
+// Supports method chaining
+func (t *TDigest[T]) Add(values ...T) *TDigest[T] {
+	for _, value := range values {
+		t.addCentroid(centroid{mean: float64(value), weight: 1})
+	}
+	return t
+}
+
+// Merge combines the values digested by other into the digest
+//
+// Supports method chaining
+func (t *TDigest[T]) Merge(other *TDigest[T]) *TDigest[T] {
+	if other.count == 0 {
+		return t
+	}
+	t.init()
+	for _, c := range other.centroids {
+		t.addCentroid(c)
+	}
+	for _, c := range other.buffer {
+		t.addCentroid(c)
+	}
+	t.min = min(t.min, other.min)
+	t.max = max(t.max, other.max)
+	return t
+}
+
+// Count returns the number of values added
+func (t *TDigest[T]) Count() uint64 {
+	return uint64(t.count)
+}
+
+// Quantile returns the estimated q-quantile of the values for q in [0, 1]
+//
+// Errors: EmptyListError, QuantileRangeError
+func (t *TDigest[T]) Quantile(q float64) (float64, error) {
+	if t.count == 0 {
+		return 0, kl.EmptyListError
+	}
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, QuantileRangeError
+	}
+	t.compress()
+	if len(t.centroids) == 1 || q == 0 {
+		return t.interpolateEnds(q), nil
+	}
+
+	// Each centroid's mean is placed at the middle of its weight
+	target := q * t.count
+	first, last := t.centroids[0], t.centroids[len(t.centroids)-1]
+	if target < first.weight/2 {
+		return t.min + (first.mean-t.min)*target/(first.weight/2), nil
+	}
+	if target > t.count-last.weight/2 {
+		return last.mean + (t.max-last.mean)*(target-(t.count-last.weight/2))/(last.weight/2), nil
+	}
+	cumulative := first.weight / 2
+	for i := 1; i < len(t.centroids); i++ {
+		prev, next := t.centroids[i-1], t.centroids[i]
+		step := (prev.weight + next.weight) / 2
+		if target <= cumulative+step {
+			return prev.mean + (next.mean-prev.mean)*(target-cumulative)/step, nil
+		}
+		cumulative += step
+	}
+	return last.mean, nil
+}
+
+// Percentile returns the estimated p-th percentile of the values for p in [0, 100]
+//
+// Errors: EmptyListError, QuantileRangeError
+func (t *TDigest[T]) Percentile(p float64) (float64, error) {
+	return t.Quantile(p / 100)
+}
+
+// Median returns the estimated median of the values
+//
+// Errors: EmptyListError
+func (t *TDigest[T]) Median() (float64, error) {
+	return t.Quantile(0.5)
+}
+
+// Clear resets the digest, keeping its compression
+func (t *TDigest[T]) Clear() *TDigest[T] {
+	t.init()
+	*t = *NewTDigest[T](t.compression)
+	return t
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (t *TDigest[T]) MarshalBinary() ([]byte, error) {
+	t.init()
+	t.compress()
+	data := make([]byte, 0, 2+4*8+len(t.centroids)*16)
+	data = append(data, 'T', tDigestEncodingVersion)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(t.compression))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(t.min))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(t.max))
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(t.centroids)))
+	for _, c := range t.centroids {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(c.mean))
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(c.weight))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+//
+// Errors: InvalidEncodingError
+func (t *TDigest[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2+4*8 || data[0] != 'T' || data[1] != tDigestEncodingVersion {
+		return InvalidEncodingError
+	}
+	compression := math.Float64frombits(binary.LittleEndian.Uint64(data[2:]))
+	minimum := math.Float64frombits(binary.LittleEndian.Uint64(data[10:]))
+	maximum := math.Float64frombits(binary.LittleEndian.Uint64(data[18:]))
+	n := binary.LittleEndian.Uint64(data[26:])
+	data = data[34:]
+	// n is checked against the data first so the size cannot overflow
+	if !(compression >= 1) || math.IsInf(compression, 1) || n > uint64(len(data))/16 || uint64(len(data)) != n*16 {
+		return InvalidEncodingError
+	}
+	result := TDigest[T]{compression: compression, min: minimum, max: maximum, centroids: make([]centroid, n)}
+	for i := range result.centroids {
+		c := centroid{
+			mean:   math.Float64frombits(binary.LittleEndian.Uint64(data[i*16:])),
+			weight: math.Float64frombits(binary.LittleEndian.Uint64(data[i*16+8:])),
+		}
+		if !(c.weight > 0) {
+			return InvalidEncodingError
+		}
+		result.centroids[i] = c
+		result.count += c.weight
+	}
+	*t = result
+	return nil
+}
+
+// init gives the zero value its default compression and empty bounds
+func (t *TDigest[T]) init() {
+	if t.compression == 0 {
+		*t = *NewTDigest[T](DefaultCompression)
+	}
+}
+
+func (t *TDigest[T]) addCentroid(c centroid) {
+	t.init()
+	t.buffer = append(t.buffer, c)
+	t.count += c.weight
+	t.min = min(t.min, c.mean)
+	t.max = max(t.max, c.mean)
+	if len(t.buffer) >= int(5*t.compression) {
+		t.compress()
+	}
+}
+
+// compress merges the buffered values into the centroids, allowing large centroids
+// in the middle of the distribution and keeping them small at the tails
+func (t *TDigest[T]) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	all := append(t.centroids, t.buffer...)
+	slices.SortFunc(all, func(a, b centroid) int {
+		return cmp.Compare(a.mean, b.mean)
+	})
+
+	merged := make([]centroid, 0, len(t.centroids)+1)
+	current := all[0]
+	weightSoFar := 0.0
+	for _, c := range all[1:] {
+		q := (weightSoFar + current.weight + c.weight) / t.count
+		limit := 4 * t.count * q * (1 - q) / t.compression
+		if current.weight+c.weight <= limit {
+			current.weight += c.weight
+			current.mean += (c.mean - current.mean) * c.weight / current.weight
+			continue
+		}
+		weightSoFar += current.weight
+		merged = append(merged, current)
+		current = c
+	}
+	t.centroids = append(merged, current)
+	t.buffer = t.buffer[:0]
+}
+
+func (t *TDigest[T]) interpolateEnds(q float64) float64 {
+	return t.min + (t.max-t.min)*q
+}
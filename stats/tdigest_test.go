@@ -0,0 +1,173 @@
+package kstat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+func TestTDigestAccuracy(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	values := make([]float64, 100_000)
+	for i := range values {
+		values[i] = r.NormFloat64()
+	}
+	d := NewTDigest[float64](DefaultCompression).Add(values...)
+	sorted := slices.Sorted(slices.Values(values))
+
+	for _, q := range []float64{0, 0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 1} {
+		got, err := d.Quantile(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Compare ranks rather than values: the digest promises accuracy in q
+		rank := float64(countBelow(sorted, got)) / float64(len(sorted))
+		if math.Abs(rank-q) > 0.01 {
+			t.Errorf("Quantile(%v) = %v, which has rank %v", q, got, rank)
+		}
+	}
+	if got, _ := d.Quantile(0); got != sorted[0] {
+		t.Errorf("Quantile(0) = %v, want minimum %v", got, sorted[0])
+	}
+	if got, _ := d.Quantile(1); got != sorted[len(sorted)-1] {
+		t.Errorf("Quantile(1) = %v, want maximum %v", got, sorted[len(sorted)-1])
+	}
+	if d.Count() != uint64(len(values)) {
+		t.Errorf("Count = %d, want %d", d.Count(), len(values))
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	whole := NewTDigest[int](DefaultCompression)
+	parts := []*TDigest[int]{
+		NewTDigest[int](DefaultCompression),
+		NewTDigest[int](DefaultCompression),
+		NewTDigest[int](DefaultCompression),
+	}
+	for i := range 30_000 {
+		whole.Add(i)
+		parts[i%3].Add(i)
+	}
+	merged := NewTDigest[int](DefaultCompression)
+	for _, p := range parts {
+		merged.Merge(p)
+	}
+	if merged.Count() != whole.Count() {
+		t.Fatalf("merged Count = %d, want %d", merged.Count(), whole.Count())
+	}
+	for _, q := range []float64{0, 0.05, 0.5, 0.95, 1} {
+		got, _ := merged.Quantile(q)
+		if want := q * 29_999; math.Abs(got-want) > 300 {
+			t.Errorf("merged Quantile(%v) = %v, want about %v", q, got, want)
+		}
+	}
+}
+
+func TestTDigestZeroValue(t *testing.T) {
+	var d TDigest[int]
+	if _, err := d.Median(); !errors.Is(err, kl.EmptyListError) {
+		t.Fatalf("Median of empty digest = %v, want EmptyListError", err)
+	}
+	for i := range 1000 {
+		d.Add(i)
+	}
+	if got, _ := d.Quantile(0); got != 0 {
+		t.Fatalf("Quantile(0) = %v, want 0", got)
+	}
+	if got, _ := d.Median(); math.Abs(got-500) > 10 {
+		t.Fatalf("Median = %v, want about 500", got)
+	}
+
+	var merged TDigest[int]
+	merged.Merge(&d)
+	if got, _ := merged.Quantile(1); got != 999 {
+		t.Fatalf("Quantile(1) after Merge into zero value = %v, want 999", got)
+	}
+}
+
+func TestTDigestRoundTrip(t *testing.T) {
+	d := NewTDigest[float64](50)
+	for i := range 10_000 {
+		d.Add(float64(i % 977))
+	}
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TDigest[float64]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []float64{0, 0.3, 0.5, 0.99, 1} {
+		want, _ := d.Quantile(q)
+		if got, _ := decoded.Quantile(q); got != want {
+			t.Errorf("decoded Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+	if decoded.Count() != d.Count() {
+		t.Errorf("decoded Count = %d, want %d", decoded.Count(), d.Count())
+	}
+}
+
+func TestTDigestRejectsBadHeaders(t *testing.T) {
+	header := func(compression float64, n uint64) []byte {
+		data := []byte{'T', tDigestEncodingVersion}
+		for _, w := range []uint64{math.Float64bits(compression), 0, 0, n} {
+			data = binary.LittleEndian.AppendUint64(data, w)
+		}
+		return data
+	}
+	for name, data := range map[string][]byte{
+		// n*16 wraps to 0, matching the empty body
+		"wrapping n":        header(100, 1<<60),
+		"n past data":       append(header(100, 2), make([]byte, 16)...),
+		"NaN compression":   header(math.NaN(), 0),
+		"Inf compression":   header(math.Inf(1), 0),
+		"-Inf compression":  header(math.Inf(-1), 0),
+		"small compression": header(0.5, 0),
+	} {
+		var d TDigest[float64]
+		if err := d.UnmarshalBinary(data); !errors.Is(err, InvalidEncodingError) {
+			t.Errorf("%s: UnmarshalBinary = %v, want InvalidEncodingError", name, err)
+		}
+	}
+}
+
+func FuzzOnlineBinary(f *testing.F) {
+	for _, m := range []interface{ MarshalBinary() ([]byte, error) }{
+		NewTDigest[float64](10).Add(1, 2, 3),
+		NewSummary[float64](1, 2),
+		NewEMA[float64](0.5).Add(1, 2),
+	} {
+		data, _ := m.MarshalBinary()
+		f.Add(data)
+	}
+
+	// Whatever decodes must be usable without panicking
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d TDigest[float64]
+		if d.UnmarshalBinary(data) == nil {
+			d.Add(1, 2, 3)
+			_, _ = d.Quantile(0.5)
+			d.Merge(NewTDigest[float64](10).Add(4))
+		}
+		var s Summary[float64]
+		if s.UnmarshalBinary(data) == nil {
+			s.Add(1).Merge(NewSummary[float64](2))
+		}
+		var e EMA[float64]
+		if e.UnmarshalBinary(data) == nil {
+			e.Add(1)
+		}
+	})
+}
+
+func countBelow(sorted []float64, v float64) int {
+	i, _ := slices.BinarySearch(sorted, v)
+	return i
+}
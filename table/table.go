@@ -0,0 +1,233 @@
+// Package kt renders lists of structs, such as kp.Pair, as aligned ASCII or Markdown tables
+package kt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	kcodec "github.com/KeylimeVI/keylime-go/internal/codec"
+	kl "github.com/KeylimeVI/keylime-go/list"
+)
+
+// UnknownColumnError is returned when Config.Columns names a column the element type does not have
+var UnknownColumnError = errors.New("unknown column")
+
+var (
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+	errorType    = reflect.TypeFor[error]()
+)
+
+// Style is the syntax a table is rendered in
+type Style int
+
+const (
+	// ASCII draws the table with +, - and | borders
+	ASCII Style = iota
+	// Markdown renders a GitHub flavored Markdown table
+	Markdown
+)
+
+// Config configures how a table is rendered. The zero value renders every column as ASCII.
+type Config struct {
+	// Style is the table syntax, ASCII by default
+	Style Style
+	// Columns selects and orders the columns by name. All columns are shown in field order if empty.
+	Columns []string
+}
+
+// column is a rendered column with its cells
+type column struct {
+	name  string
+	cells []string
+	right bool
+	width int
+}
+
+// Render renders the list as a table, see Write
+//
+// Errors: UnknownColumnError
+func Render[T any](list kl.List[T], config Config) (string, error) {
+	var b strings.Builder
+	if err := Write(&b, list, config); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Write renders the list as a table to w.
+// Struct elements get one column per exported field, named by its `table` tag or field name (a tag of "-" skips it),
+// so a kp.Pair has columns A and B. Pointers to structs are followed, and other pointers, as elements or fields,
+// show the value they point to unless they implement fmt.Stringer or error. Nil pointers give empty cells.
+// Any other element type is shown in a single column named Value.
+// Numeric columns are right aligned.
+//
+// Errors: UnknownColumnError, any error from the writer
+func Write[T any](w io.Writer, list kl.List[T], config Config) error {
+	columns, err := buildColumns(list, config.Columns)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	switch config.Style {
+	case Markdown:
+		writeMarkdown(&b, columns, len(list))
+	default:
+		writeASCII(&b, columns, len(list))
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func buildColumns[T any](list kl.List[T], selected []string) ([]column, error) {
+	t := reflect.TypeFor[T]()
+	pointer := t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct
+	if pointer {
+		t = t.Elem()
+	}
+
+	var fields []kcodec.Field
+	if t.Kind() == reflect.Struct {
+		fields = kcodec.StructFields(t, "table")
+	} else {
+		fields = []kcodec.Field{{Name: "Value", Type: t}}
+	}
+	if len(selected) > 0 {
+		byName := make(map[string]kcodec.Field, len(fields))
+		for _, f := range fields {
+			byName[f.Name] = f
+		}
+		fields = fields[:0:0]
+		for _, name := range selected {
+			f, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", UnknownColumnError, name)
+			}
+			fields = append(fields, f)
+		}
+	}
+
+	columns := make([]column, len(fields))
+	for i, f := range fields {
+		columns[i] = column{name: f.Name, cells: make([]string, len(list)), right: numeric(f.Type), width: utf8.RuneCountInString(f.Name)}
+	}
+	for row := range list {
+		value := reflect.ValueOf(&list[row]).Elem()
+		if pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		for i, f := range fields {
+			v := value
+			if f.Index != nil {
+				v = value.FieldByIndex(f.Index)
+			}
+			cell := cellText(v)
+			columns[i].cells[row] = cell
+			columns[i].width = max(columns[i].width, utf8.RuneCountInString(cell))
+		}
+	}
+	return columns, nil
+}
+
+func writeASCII(b *strings.Builder, columns []column, rows int) {
+	border := func() {
+		b.WriteByte('+')
+		for _, c := range columns {
+			b.WriteString(strings.Repeat("-", c.width+2))
+			b.WriteByte('+')
+		}
+		b.WriteByte('\n')
+	}
+	line := func(cell func(c column) string) {
+		b.WriteByte('|')
+		for _, c := range columns {
+			b.WriteByte(' ')
+			b.WriteString(pad(cell(c), c.width, c.right))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+	border()
+	line(func(c column) string { return c.name })
+	border()
+	for row := 0; row < rows; row++ {
+		line(func(c column) string { return c.cells[row] })
+	}
+	if rows > 0 {
+		border()
+	}
+}
+
+func writeMarkdown(b *strings.Builder, columns []column, rows int) {
+	escape := strings.NewReplacer("|", `\|`)
+	for i := range columns {
+		c := &columns[i]
+		c.name = escape.Replace(c.name)
+		for row := range c.cells {
+			c.cells[row] = escape.Replace(c.cells[row])
+			c.width = max(c.width, utf8.RuneCountInString(c.cells[row]))
+		}
+		c.width = max(c.width, utf8.RuneCountInString(c.name), 3)
+	}
+	line := func(cell func(c column) string) {
+		b.WriteByte('|')
+		for _, c := range columns {
+			b.WriteByte(' ')
+			b.WriteString(pad(cell(c), c.width, c.right))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+	line(func(c column) string { return c.name })
+	line(func(c column) string {
+		if c.right {
+			return strings.Repeat("-", c.width-1) + ":"
+		}
+		return strings.Repeat("-", c.width)
+	})
+	for row := 0; row < rows; row++ {
+		line(func(c column) string { return c.cells[row] })
+	}
+}
+
+// cellText formats a value on a single line, dereferencing pointers that do not format themselves
+func cellText(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		if v.Type().Implements(stringerType) || v.Type().Implements(errorType) {
+			break
+		}
+		v = v.Elem()
+	}
+	return strings.Join(strings.Fields(fmt.Sprintf("%v", v.Interface())), " ")
+}
+
+func pad(text string, width int, right bool) string {
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(text))
+	if right {
+		return padding + text
+	}
+	return text + padding
+}
+
+func numeric(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
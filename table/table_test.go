@@ -0,0 +1,149 @@
+package kt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	kl "github.com/KeylimeVI/keylime-go/list"
+	kp "github.com/KeylimeVI/keylime-go/pair"
+)
+
+type item struct {
+	Name     string
+	Price    float64 `table:"Unit price"`
+	Quantity *int
+	internal int
+	Secret   string `table:"-"`
+}
+
+func render[T any](t *testing.T, list kl.List[T], config Config) string {
+	t.Helper()
+	got, err := Render(list, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func golden(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestRenderASCII(t *testing.T) {
+	three := 3
+	items := kl.List[item]{
+		{Name: "apple", Price: 1.5, Quantity: &three, Secret: "x"},
+		{Name: "kiwi | gold", Price: 12, internal: 7},
+	}
+	want := golden(
+		"+-------------+------------+----------+",
+		"| Name        | Unit price | Quantity |",
+		"+-------------+------------+----------+",
+		"| apple       |        1.5 |        3 |",
+		"| kiwi | gold |         12 |          |",
+		"+-------------+------------+----------+",
+	)
+	if got := render(t, items, Config{}); got != want {
+		t.Fatalf("ASCII table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	items := kl.List[item]{
+		{Name: "a|b", Price: 2.25},
+		{Name: "multi\nline  text", Price: 100},
+	}
+	want := golden(
+		"| Name            | Unit price | Quantity |",
+		"| --------------- | ---------: | -------: |",
+		`| a\|b            |       2.25 |          |`,
+		"| multi line text |        100 |          |",
+	)
+	if got := render(t, items, Config{Style: Markdown}); got != want {
+		t.Fatalf("Markdown table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderColumns(t *testing.T) {
+	items := kl.List[item]{{Name: "pear", Price: 3}}
+	want := golden(
+		"+------------+------+",
+		"| Unit price | Name |",
+		"+------------+------+",
+		"|          3 | pear |",
+		"+------------+------+",
+	)
+	if got := render(t, items, Config{Columns: []string{"Unit price", "Name"}}); got != want {
+		t.Fatalf("selected columns:\n%s\nwant:\n%s", got, want)
+	}
+
+	for _, name := range []string{"Price", "Secret", "internal", "Missing"} {
+		_, err := Render(items, Config{Columns: []string{"Name", name}})
+		if !errors.Is(err, UnknownColumnError) || !strings.Contains(err.Error(), name) {
+			t.Errorf("column %q: Render = %v, want UnknownColumnError naming it", name, err)
+		}
+	}
+}
+
+func TestRenderPointerRows(t *testing.T) {
+	items := kl.List[*item]{{Name: "fig", Price: 4}, nil}
+	want := golden(
+		"| Name | Unit price | Quantity |",
+		"| ---- | ---------: | -------: |",
+		"| fig  |          4 |          |",
+		"|      |            |          |",
+	)
+	if got := render(t, items, Config{Style: Markdown}); got != want {
+		t.Fatalf("pointer rows:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderPairs(t *testing.T) {
+	pairs := kl.List[kp.Pair[string, int]]{kp.NewPair("one", 1), kp.NewPair("eleven", 11)}
+	want := golden(
+		"+--------+----+",
+		"| A      |  B |",
+		"+--------+----+",
+		"| one    |  1 |",
+		"| eleven | 11 |",
+		"+--------+----+",
+	)
+	if got := render(t, pairs, Config{}); got != want {
+		t.Fatalf("pairs:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderValues(t *testing.T) {
+	one, twenty := 1, 20
+	want := golden(
+		"+-------+",
+		"| Value |",
+		"+-------+",
+		"|     1 |",
+		"|       |",
+		"|    20 |",
+		"+-------+",
+	)
+	if got := render(t, kl.List[*int]{&one, nil, &twenty}, Config{}); got != want {
+		t.Fatalf("pointer values:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = golden(
+		"| Value |",
+		"| ----- |",
+		"| x     |",
+	)
+	if got := render(t, kl.List[string]{"x"}, Config{Style: Markdown}); got != want {
+		t.Fatalf("string values:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = golden(
+		"+-------+",
+		"| Value |",
+		"+-------+",
+	)
+	if got := render(t, kl.List[int]{}, Config{}); got != want {
+		t.Fatalf("empty list:\n%s\nwant:\n%s", got, want)
+	}
+}